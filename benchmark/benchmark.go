@@ -0,0 +1,180 @@
+// Package benchmark runs a problem repeatedly, with different seeds and
+// solver settings, and summarizes how well each setting did.
+package benchmark
+
+import (
+	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/problems"
+	"math"
+	s "sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Setting names a way of configuring the solver.
+type Setting struct {
+	Name      string
+	Configure func(solver *genetic.Solver)
+}
+
+// Run describes a single solve.
+type Run struct {
+	Seed                int64   `json:"seed"`
+	Fitness             int     `json:"fitness"`
+	ReachedTarget       bool    `json:"reachedTarget"`
+	SecondsToTarget     float64 `json:"secondsToTarget,omitempty"`
+	EvaluationsToTarget int64   `json:"evaluationsToTarget,omitempty"`
+	Seconds             float64 `json:"seconds"`
+	Evaluations         int64   `json:"evaluations"`
+}
+
+// Distribution summarizes a set of measurements.
+type Distribution struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P10    float64 `json:"p10"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// Result holds every run of a problem with one setting, and their summary.
+// The time and evaluation distributions only cover runs that reached the
+// target.
+type Result struct {
+	Problem             string       `json:"problem"`
+	Setting             string       `json:"setting"`
+	SuccessRate         float64      `json:"successRate"`
+	Fitness             Distribution `json:"fitness"`
+	SecondsToTarget     Distribution `json:"secondsToTarget"`
+	EvaluationsToTarget Distribution `json:"evaluationsToTarget"`
+	Runs                []Run        `json:"runs"`
+}
+
+// Compare runs the problem the given number of times with each setting.
+// Run i of every setting uses seed firstSeed+i so settings are compared on
+// the same seeds. Seed 0 leaves the solver unseeded, so the count skips it.
+func Compare(problem problems.Problem, settings []Setting, runs int, firstSeed int64) []Result {
+	results := make([]Result, 0, len(settings))
+	for _, setting := range settings {
+		results = append(results, Measure(problem, setting, runs, firstSeed))
+	}
+	return results
+}
+
+// Measure runs the problem the given number of times with one setting,
+// with seeds counted up from firstSeed as in Compare.
+func Measure(problem problems.Problem, setting Setting, runs int, firstSeed int64) Result {
+	result := Result{Problem: problem.Name, Setting: setting.Name}
+	for i := 0; i < runs; i++ {
+		result.Runs = append(result.Runs, solveOnce(problem, setting, seedOf(firstSeed, i)))
+	}
+	result.summarize()
+	return result
+}
+
+// seedOf returns the seed of run i: firstSeed+i, or one more once the
+// count has reached 0.
+func seedOf(firstSeed int64, i int) int64 {
+	seed := firstSeed + int64(i)
+	if firstSeed <= 0 && seed >= 0 {
+		seed++
+	}
+	return seed
+}
+
+func solveOnce(problem problems.Problem, setting Setting, seed int64) Run {
+	var evaluations atomic.Int64
+	var reachedTarget sync.Once
+	run := Run{Seed: seed}
+	start := time.Now()
+
+	measured := problem
+	measured.GetFitness = func(genes string) int {
+		count := evaluations.Add(1)
+		fitness := problem.GetFitness(genes)
+		if problem.ReachedTarget(fitness) {
+			reachedTarget.Do(func() {
+				run.ReachedTarget = true
+				run.SecondsToTarget = time.Since(start).Seconds()
+				run.EvaluationsToTarget = count
+			})
+		}
+		return fitness
+	}
+
+	solver := new(genetic.Solver)
+	solver.MaxSecondsToRunWithoutImprovement = 1
+	if setting.Configure != nil {
+		setting.Configure(solver)
+	}
+	solver.RandomSeed = seed
+
+	best := measured.Solve(solver, func(string) {})
+
+	run.Seconds = time.Since(start).Seconds()
+	run.Evaluations = evaluations.Load()
+	run.Fitness = problem.GetFitness(best)
+	reachedTarget.Do(func() {}) // waits for, and publishes, any earlier Do
+	return run
+}
+
+func (result *Result) summarize() {
+	var fitnesses, seconds, evaluations []float64
+	for _, run := range result.Runs {
+		fitnesses = append(fitnesses, float64(run.Fitness))
+		if run.ReachedTarget {
+			seconds = append(seconds, run.SecondsToTarget)
+			evaluations = append(evaluations, float64(run.EvaluationsToTarget))
+		}
+	}
+	if len(result.Runs) > 0 {
+		result.SuccessRate = float64(len(seconds)) / float64(len(result.Runs))
+	}
+	result.Fitness = Summarize(fitnesses)
+	result.SecondsToTarget = Summarize(seconds)
+	result.EvaluationsToTarget = Summarize(evaluations)
+}
+
+// Summarize computes the distribution of the values.
+func Summarize(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]float64(nil), values...)
+	s.Float64s(sorted)
+
+	total := 0.0
+	for _, value := range sorted {
+		total += value
+	}
+	return Distribution{
+		Count:  len(sorted),
+		Mean:   total / float64(len(sorted)),
+		Median: Percentile(sorted, 50),
+		P10:    Percentile(sorted, 10),
+		P25:    Percentile(sorted, 25),
+		P75:    Percentile(sorted, 75),
+		P90:    Percentile(sorted, 90),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// Percentile linearly interpolates the given percentile of sorted values.
+func Percentile(sorted []float64, percent float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	position := percent / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (position-float64(lower))*(sorted[upper]-sorted[lower])
+}
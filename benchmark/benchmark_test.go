@@ -0,0 +1,104 @@
+package benchmark
+
+import (
+	"bytes"
+	"encoding/json"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/problems"
+	"strings"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	distribution := Summarize([]float64{5, 1, 4, 2, 3})
+
+	if distribution.Count != 5 || distribution.Mean != 3 || distribution.Median != 3 ||
+		distribution.Min != 1 || distribution.Max != 5 {
+		t.Errorf("unexpected distribution %+v", distribution)
+	}
+	if distribution.P10 != 1.4 || distribution.P90 != 4.6 {
+		t.Errorf("expected interpolated percentiles 1.4 and 4.6, got %v and %v", distribution.P10, distribution.P90)
+	}
+	if (Summarize(nil) != Distribution{}) {
+		t.Error("expected an empty distribution for no values")
+	}
+}
+
+func TestSeedsSkipZero(t *testing.T) {
+	result := Measure(problems.OneMax(8), Setting{Configure: func(solver *genetic.Solver) {
+		solver.MaxSecondsToRunWithoutImprovement = .01
+	}}, 3, -1)
+
+	for i, expected := range []int64{-1, 1, 2} {
+		if seed := result.Runs[i].Seed; seed != expected {
+			t.Errorf("run %d: expected seed %d, got %d", i, expected, seed)
+		}
+	}
+}
+
+func TestCompareReportsEverySetting(t *testing.T) {
+	quick := func(solver *genetic.Solver) { solver.MaxSecondsToRunWithoutImprovement = .05 }
+	results := Compare(problems.OneMax(8), []Setting{
+		{Name: "uniform", Configure: quick},
+		{Name: "tournament", Configure: func(solver *genetic.Solver) {
+			quick(solver)
+			solver.Selection = genetic.TournamentSelection
+		}},
+	}, 2, 10)
+
+	if len(results) != 2 || len(results[0].Runs) != 2 || len(results[1].Runs) != 2 {
+		t.Fatalf("expected 2 settings with 2 runs each, got %+v", results)
+	}
+	if results[0].Runs[0].Seed != 10 || results[0].Runs[1].Seed != 11 || results[1].Runs[0].Seed != 10 {
+		t.Error("expected every setting to use the same seeds")
+	}
+	for _, result := range results {
+		for _, run := range result.Runs {
+			if run.Evaluations == 0 {
+				t.Errorf("%s: expected evaluations to be counted", result.Setting)
+			}
+			if run.ReachedTarget && (run.EvaluationsToTarget == 0 || run.EvaluationsToTarget > run.Evaluations) {
+				t.Errorf("%s: unexpected evaluations to target %v of %v", result.Setting, run.EvaluationsToTarget, run.Evaluations)
+			}
+		}
+	}
+
+	var table, encoded bytes.Buffer
+	if err := WriteTable(&table, results); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(table.String(), "tournament") {
+		t.Errorf("expected the table to list every setting, got:\n%s", table.String())
+	}
+	if err := WriteJSON(&encoded, results); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Result
+	if err := json.Unmarshal(encoded.Bytes(), &decoded); err != nil || len(decoded) != 2 {
+		t.Errorf("expected JSON to round trip, got %v", err)
+	}
+}
+
+// BenchmarkEvaluationsPerSecond runs each sample problem for a fixed
+// stretch and reports how many candidates the solver evaluated per second.
+func BenchmarkEvaluationsPerSecond(b *testing.B) {
+	for _, problem := range []problems.Problem{
+		problems.StringDuplication("Not all those who wander are lost."),
+		problems.Queens(8),
+		problems.OneMax(100),
+	} {
+		b.Run(problem.Name, func(b *testing.B) {
+			setting := Setting{Configure: func(solver *genetic.Solver) {
+				solver.MaxSecondsToRunWithoutImprovement = .2
+			}}
+			var evaluations int64
+			var seconds float64
+			for i := 0; i < b.N; i++ {
+				run := Measure(problem, setting, 1, int64(i+1)).Runs[0]
+				evaluations += run.Evaluations
+				seconds += run.Seconds
+			}
+			b.ReportMetric(float64(evaluations)/seconds, "evals/s")
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteTable writes one row per result.
+func WriteTable(w io.Writer, results []Result) error {
+	table := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(table, "problem\tsetting\truns\tsuccess\tfitness mean\tmedian\tp10\tp90\tseconds to target mean\tmedian\tp90\tevaluations to target mean\tmedian\tp90\t")
+	for _, result := range results {
+		fmt.Fprintf(table, "%s\t%s\t%d\t%.0f%%\t%.2f\t%.2f\t%.2f\t%.2f\t%.3f\t%.3f\t%.3f\t%.0f\t%.0f\t%.0f\t\n",
+			result.Problem, result.Setting, len(result.Runs), 100*result.SuccessRate,
+			result.Fitness.Mean, result.Fitness.Median, result.Fitness.P10, result.Fitness.P90,
+			result.SecondsToTarget.Mean, result.SecondsToTarget.Median, result.SecondsToTarget.P90,
+			result.EvaluationsToTarget.Mean, result.EvaluationsToTarget.Median, result.EvaluationsToTarget.P90)
+	}
+	return table.Flush()
+}
+
+// WriteJSON writes the results, including every run, as indented JSON.
+func WriteJSON(w io.Writer, results []Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
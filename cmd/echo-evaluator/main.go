@@ -0,0 +1,24 @@
+// Command echo-evaluator serves fitness.ServeEcho on standard input and
+// output, a stand-in for a real evaluator when trying out or testing
+// fitness.Process and geneticgo.
+//
+//	echo-evaluator [-protocol line|length-prefixed]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/handcraftsman/GeneticGo/fitness"
+	"os"
+)
+
+func main() {
+	protocol := fitness.LineProtocol
+	flag.TextVar(&protocol, "protocol", fitness.LineProtocol, "framing of candidates and fitnesses: line or length-prefixed")
+	flag.Parse()
+
+	if err := fitness.ServeEcho(os.Stdin, os.Stdout, protocol); err != nil {
+		fmt.Fprintln(os.Stderr, "echo-evaluator:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,86 @@
+// Command geneticbench runs a built-in problem repeatedly with one or more
+// solver settings and reports how each setting did.
+//
+//	geneticbench -problem queens:8 -runs 20 \
+//	    -setting default: \
+//	    -setting tournament:Selection=tournament,TournamentSize=3
+package main
+
+import (
+	"flag"
+	"fmt"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/benchmark"
+	"github.com/handcraftsman/GeneticGo/internal/solverconfig"
+	"github.com/handcraftsman/GeneticGo/problems"
+	"os"
+	"strings"
+)
+
+type settingsFlag []benchmark.Setting
+
+func (settings *settingsFlag) String() string {
+	names := make([]string, 0, len(*settings))
+	for _, setting := range *settings {
+		names = append(names, setting.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// Set parses name:Field=value,Field=value
+func (settings *settingsFlag) Set(text string) error {
+	name, assignments, _ := strings.Cut(text, ":")
+	values := make(map[string]interface{})
+	for _, assignment := range strings.Split(assignments, ",") {
+		if assignment == "" {
+			continue
+		}
+		field, value, found := strings.Cut(assignment, "=")
+		if !found {
+			return fmt.Errorf("expected Field=value, got %q", assignment)
+		}
+		values[field] = value
+	}
+	if err := solverconfig.Apply(new(genetic.Solver), values); err != nil {
+		return err
+	}
+
+	*settings = append(*settings, benchmark.Setting{
+		Name: name,
+		Configure: func(solver *genetic.Solver) {
+			solverconfig.Apply(solver, values)
+		},
+	})
+	return nil
+}
+
+func main() {
+	var settings settingsFlag
+	problemName := flag.String("problem", "string-duplication", "problem to solve, one of "+strings.Join(problems.Names(), ", ")+", with an optional :parameter")
+	runs := flag.Int("runs", 10, "runs per setting")
+	seed := flag.Int64("seed", 1, "seed of the first run, later runs count up from it, skipping 0")
+	asJSON := flag.Bool("json", false, "write JSON instead of a table")
+	flag.Var(&settings, "setting", "name:Field=value,... solver setting to compare, may be repeated; fields: "+strings.Join(solverconfig.Names(), ", "))
+	flag.Parse()
+
+	problem, err := problems.Lookup(*problemName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if len(settings) == 0 {
+		settings.Set("default:")
+	}
+
+	results := benchmark.Compare(problem, settings, *runs, *seed)
+
+	if *asJSON {
+		err = benchmark.WriteJSON(os.Stdout, results)
+	} else {
+		err = benchmark.WriteTable(os.Stdout, results)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
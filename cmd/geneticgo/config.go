@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/fitness"
+	"github.com/handcraftsman/GeneticGo/internal/solverconfig"
+	"github.com/handcraftsman/GeneticGo/problems"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type config struct {
+	GeneSet                    string                 `json:"geneSet"`
+	NumberOfChromosomes        int                    `json:"numberOfChromosomes"`
+	NumberOfGenesPerChromosome int                    `json:"numberOfGenesPerChromosome"`
+	Mode                       string                 `json:"mode"`
+	Solver                     map[string]interface{} `json:"solver"`
+	Termination                terminationConfig      `json:"termination"`
+	Fitness                    fitnessConfig          `json:"fitness"`
+}
+
+type terminationConfig struct {
+	TargetFitness *int    `json:"targetFitness"`
+	MaxSeconds    float64 `json:"maxSeconds"`
+}
+
+type fitnessConfig struct {
+	Problem        string           `json:"problem"`
+	Command        []string         `json:"command"`
+	Protocol       fitness.Protocol `json:"protocol"`
+	Processes      int              `json:"processes"`
+	BatchSize      int              `json:"batchSize"`
+	TimeoutSeconds float64          `json:"timeoutSeconds"`
+}
+
+// loadConfig reads a JSON config, or a TOML one if the file name ends in
+// .toml. Both use the same keys.
+func loadConfig(path string) (*config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		values, err := parseTOML(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if content, err = json.Marshal(values); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+
+	var c config
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.DisallowUnknownFields()
+	decoder.UseNumber()
+	if err := decoder.Decode(&c); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for name, value := range c.Solver {
+		if number, ok := value.(json.Number); ok {
+			c.Solver[name] = number.String()
+		}
+	}
+	return &c, nil
+}
+
+// problem combines the fitness provider with the layout and mode in the
+// config. Anything the config leaves out comes from the built-in problem,
+// if one was named. The solver must already be configured.
+func (c *config) problem(solver *genetic.Solver) (problems.Problem, error) {
+	var problem problems.Problem
+	switch {
+	case c.Fitness.Problem != "" && len(c.Fitness.Command) > 0:
+		return problem, fmt.Errorf("fitness: give either a problem or a command, not both")
+	case c.Fitness.Problem != "":
+		var err error
+		if problem, err = problems.Lookup(c.Fitness.Problem); err != nil {
+			return problem, err
+		}
+	case len(c.Fitness.Command) > 0:
+		problem.Name = filepath.Base(c.Fitness.Command[0])
+	default:
+		return problem, fmt.Errorf("fitness: give a problem, one of %v, or an evaluator command", problems.Names())
+	}
+
+	if c.GeneSet != "" {
+		problem.GeneSet = c.GeneSet
+	}
+	if c.NumberOfChromosomes > 0 {
+		problem.NumberOfChromosomes = c.NumberOfChromosomes
+	}
+	if c.NumberOfGenesPerChromosome > 0 {
+		problem.NumberOfGenesPerChromosome = c.NumberOfGenesPerChromosome
+	}
+	if problem.NumberOfGenesPerChromosome == 0 {
+		problem.NumberOfGenesPerChromosome = 1
+	}
+	for name := range c.Solver {
+		if strings.EqualFold(name, "LowerFitnessesAreBetter") {
+			problem.LowerFitnessesAreBetter = solver.LowerFitnessesAreBetter
+		}
+	}
+	if c.Termination.TargetFitness != nil {
+		problem.TargetFitness = *c.Termination.TargetFitness
+	}
+
+	switch c.Mode {
+	case "":
+	case "fixed":
+		problem.HillClimbing = false
+	case "hill-climbing":
+		problem.HillClimbing = true
+	default:
+		return problem, fmt.Errorf("mode: expected fixed or hill-climbing, got %q", c.Mode)
+	}
+
+	switch {
+	case problem.GeneSet == "":
+		return problem, fmt.Errorf("geneSet is required")
+	case strings.ContainsAny(problem.GeneSet, "\r\n"):
+		return problem, fmt.Errorf("geneSet cannot contain line breaks")
+	case problem.NumberOfChromosomes < 1:
+		return problem, fmt.Errorf("numberOfChromosomes is required")
+	case problem.HillClimbing && c.Fitness.Problem == "" && c.Termination.TargetFitness == nil:
+		return problem, fmt.Errorf("termination.targetFitness is required when hill climbing")
+	}
+	return problem, nil
+}
+
+// evaluator creates the pool of child processes behind an evaluator
+// command. Candidates the children fail to evaluate are given the worst
+// possible fitness, or when hill climbing a negative one, which the solver
+// treats as invalid.
+func (c *config) evaluator(problem problems.Problem) *fitness.Process {
+	invalid := -1
+	switch {
+	case problem.HillClimbing:
+	case problem.LowerFitnessesAreBetter:
+		invalid = math.MaxInt32
+	default:
+		invalid = math.MinInt32
+	}
+	return &fitness.Process{
+		Command:   c.Fitness.Command,
+		Stderr:    os.Stderr,
+		Protocol:  c.Fitness.Protocol,
+		Processes: c.Fitness.Processes,
+		BatchSize: c.Fitness.BatchSize,
+		Timeout:   time.Duration(c.Fitness.TimeoutSeconds * float64(time.Second)),
+		OnError: func(genes string, err error) int {
+			fmt.Fprintln(os.Stderr, err)
+			return invalid
+		},
+	}
+}
+
+func (c *config) hasTarget() bool {
+	return c.Fitness.Problem != "" || c.Termination.TargetFitness != nil
+}
+
+func (c *config) configure(solver *genetic.Solver) error {
+	return solverconfig.Apply(solver, c.Solver)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"errors"
+	"github.com/handcraftsman/GeneticGo/problems"
+	"math"
+	"testing"
+)
+
+func TestEvaluatorGivesFailedCandidatesTheWorstFitness(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		problem problems.Problem
+		invalid int
+	}{
+		{"higher is better", problems.Problem{}, math.MinInt32},
+		{"lower is better", problems.Problem{LowerFitnessesAreBetter: true}, math.MaxInt32},
+		{"hill climbing", problems.Problem{HillClimbing: true}, -1},
+	} {
+		c := &config{Fitness: fitnessConfig{Command: []string{"./evaluate"}}}
+		evaluator := c.evaluator(test.problem)
+
+		if fitness := evaluator.OnError("01", errors.New("evaluator failed")); fitness != test.invalid {
+			t.Errorf("%s: expected %d, got %d", test.name, test.invalid, fitness)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Command geneticgo solves the problem described by a JSON or TOML config
+// file, printing progress to standard error and the result as JSON.
+//
+//	geneticgo [-o result.json] [-quiet] [-tui] problem.toml
+//
+// A config names its fitness provider, either a built-in problem or an
+// external evaluator command, and may override the layout, the mode, any
+// Solver setting and when to stop:
+//
+//	geneSet = "01"
+//	numberOfChromosomes = 64
+//	numberOfGenesPerChromosome = 1
+//	mode = "fixed" # or "hill-climbing"
+//
+//	[fitness]
+//	problem = "onemax:64"      # or
+//	# command = ["./evaluate", "--quick"]
+//
+//	[solver]
+//	MaxSecondsToRunWithoutImprovement = 2
+//	NumberOfConcurrentEvolvers = 2
+//	Selection = "tournament"
+//
+//	[termination]
+//	targetFitness = 64 # stop once reached
+//	maxSeconds = 30    # stop after this long regardless
+//
+// An evaluator command reads one candidate per line on standard input and
+// writes its integer fitness on a line to standard output, see
+// fitness.Process and cmd/echo-evaluator. The fitness table may also set
+// protocol = "length-prefixed", processes, batchSize and timeoutSeconds.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/tui"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+type result struct {
+	Problem       string  `json:"problem"`
+	Genes         string  `json:"genes"`
+	Fitness       int     `json:"fitness"`
+	ReachedTarget *bool   `json:"reachedTarget,omitempty"`
+	StoppedBy     string  `json:"stoppedBy"`
+	Seconds       float64 `json:"seconds"`
+	Evaluations   int64   `json:"evaluations"`
+}
+
+func main() {
+	outputPath := flag.String("o", "", "write the result to this file instead of standard output")
+	quiet := flag.Bool("quiet", false, "do not print progress")
+	useTUI := flag.Bool("tui", false, "show a full-screen live view instead of printing progress")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: geneticgo [-o result.json] [-quiet] [-tui] config.json|config.toml")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *outputPath, *quiet, *useTUI); err != nil {
+		fmt.Fprintln(os.Stderr, "geneticgo:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath, outputPath string, quiet, useTUI bool) error {
+	c, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	solver := new(genetic.Solver)
+	if err := c.configure(solver); err != nil {
+		return err
+	}
+	problem, err := c.problem(solver)
+	if err != nil {
+		return err
+	}
+
+	if len(c.Fitness.Command) > 0 {
+		evaluator := c.evaluator(problem)
+		defer evaluator.Close()
+		problem.GetFitness = evaluator.GetFitness
+	}
+
+	var outOfTime atomic.Bool
+	if c.Termination.MaxSeconds > 0 {
+		timer := time.AfterFunc(time.Duration(c.Termination.MaxSeconds*float64(time.Second)), func() {
+			outOfTime.Store(true)
+			solver.Stop()
+		})
+		defer timer.Stop()
+	}
+
+	start := time.Now()
+	// the fitness the solver acted on, rather than another, possibly
+	// external and costly, evaluation that might disagree with it
+	display := func(genes string) {
+		fitness := solver.Statistics().BestFitness
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "%10.3fs %10d %s\n", time.Since(start).Seconds(), fitness, genes)
+		}
+		if c.hasTarget() && problem.ReachedTarget(fitness) {
+			solver.Stop()
+		}
+	}
+
+	var view *tui.View
+	if useTUI {
+		quiet = true
+		view = tui.New(solver)
+	}
+	best := problem.Solve(solver, display)
+	if view != nil {
+		view.Close()
+	}
+
+	statistics := solver.Statistics()
+	outcome := result{
+		Problem:     problem.Name,
+		Genes:       best,
+		Fitness:     statistics.BestFitness,
+		StoppedBy:   "no improvement",
+		Seconds:     time.Since(start).Seconds(),
+		Evaluations: statistics.Evaluations,
+	}
+	if outOfTime.Load() {
+		outcome.StoppedBy = "max seconds"
+	}
+	if c.hasTarget() {
+		reachedTarget := problem.ReachedTarget(outcome.Fitness)
+		outcome.ReachedTarget = &reachedTarget
+		if reachedTarget {
+			outcome.StoppedBy = "target"
+		}
+	}
+
+	var output io.Writer = os.Stdout
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(outcome)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML reads the subset of TOML that a config needs: [table] and
+// [dotted.table] headers, key = value pairs, # comments, basic and literal
+// strings, integers, floats, booleans and single-line arrays of those.
+func parseTOML(text string) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	table := root
+	for number, line := range strings.Split(text, "\n") {
+		parser := tomlParser{text: line}
+		parser.skipSpace()
+		if parser.done() {
+			continue
+		}
+
+		var err error
+		if parser.peek() == '[' {
+			table, err = parser.tableHeader(root)
+		} else {
+			err = parser.keyValue(table)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", number+1, err)
+		}
+	}
+	return root, nil
+}
+
+type tomlParser struct {
+	text     string
+	position int
+}
+
+func (parser *tomlParser) done() bool {
+	return parser.position >= len(parser.text) || parser.text[parser.position] == '#'
+}
+
+func (parser *tomlParser) peek() byte {
+	return parser.text[parser.position]
+}
+
+func (parser *tomlParser) skipSpace() {
+	for parser.position < len(parser.text) && strings.IndexByte(" \t\r", parser.text[parser.position]) != -1 {
+		parser.position++
+	}
+}
+
+func (parser *tomlParser) expect(c byte) error {
+	parser.skipSpace()
+	if parser.position >= len(parser.text) || parser.text[parser.position] != c {
+		return fmt.Errorf("expected %q at column %d", c, parser.position+1)
+	}
+	parser.position++
+	return nil
+}
+
+func (parser *tomlParser) expectEnd() error {
+	parser.skipSpace()
+	if !parser.done() {
+		return fmt.Errorf("unexpected %q at column %d", parser.text[parser.position:], parser.position+1)
+	}
+	return nil
+}
+
+func (parser *tomlParser) tableHeader(root map[string]interface{}) (map[string]interface{}, error) {
+	parser.position++
+	table := root
+	for {
+		key, err := parser.key()
+		if err != nil {
+			return nil, err
+		}
+		child, exists := table[key]
+		if !exists {
+			child = make(map[string]interface{})
+			table[key] = child
+		}
+		childTable, ok := child.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is already a value, not a table", key)
+		}
+		table = childTable
+
+		parser.skipSpace()
+		if parser.position < len(parser.text) && parser.peek() == '.' {
+			parser.position++
+			continue
+		}
+		if err := parser.expect(']'); err != nil {
+			return nil, err
+		}
+		return table, parser.expectEnd()
+	}
+}
+
+func (parser *tomlParser) keyValue(table map[string]interface{}) error {
+	key, err := parser.key()
+	if err != nil {
+		return err
+	}
+	if _, exists := table[key]; exists {
+		return fmt.Errorf("%s is defined twice", key)
+	}
+	if err := parser.expect('='); err != nil {
+		return err
+	}
+	value, err := parser.value()
+	if err != nil {
+		return err
+	}
+	table[key] = value
+	return parser.expectEnd()
+}
+
+func (parser *tomlParser) key() (string, error) {
+	parser.skipSpace()
+	if parser.position < len(parser.text) && (parser.peek() == '"' || parser.peek() == '\'') {
+		return parser.str()
+	}
+	start := parser.position
+	for parser.position < len(parser.text) && isBareKeyChar(parser.peek()) {
+		parser.position++
+	}
+	if start == parser.position {
+		return "", fmt.Errorf("expected a key at column %d", start+1)
+	}
+	return parser.text[start:parser.position], nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (parser *tomlParser) value() (interface{}, error) {
+	parser.skipSpace()
+	if parser.position >= len(parser.text) {
+		return nil, fmt.Errorf("expected a value")
+	}
+	switch parser.peek() {
+	case '"', '\'':
+		return parser.str()
+	case '[':
+		return parser.array()
+	}
+
+	start := parser.position
+	for parser.position < len(parser.text) && strings.IndexByte(" \t\r,]#", parser.peek()) == -1 {
+		parser.position++
+	}
+	word := parser.text[start:parser.position]
+	switch word {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	number := strings.ReplaceAll(word, "_", "")
+	if integer, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return integer, nil
+	}
+	if float, err := strconv.ParseFloat(number, 64); err == nil {
+		return float, nil
+	}
+	return nil, fmt.Errorf("unsupported value %q at column %d", word, start+1)
+}
+
+func (parser *tomlParser) str() (string, error) {
+	quote := parser.peek()
+	start := parser.position
+	parser.position++
+	for parser.position < len(parser.text) {
+		switch parser.text[parser.position] {
+		case '\\':
+			if quote == '"' {
+				parser.position++
+			}
+		case quote:
+			parser.position++
+			quoted := parser.text[start:parser.position]
+			if quote == '\'' {
+				return quoted[1 : len(quoted)-1], nil
+			}
+			return strconv.Unquote(quoted)
+		}
+		parser.position++
+	}
+	return "", fmt.Errorf("unterminated string at column %d", start+1)
+}
+
+func (parser *tomlParser) array() ([]interface{}, error) {
+	parser.position++
+	values := []interface{}{}
+	for {
+		parser.skipSpace()
+		if parser.position < len(parser.text) && parser.peek() == ']' {
+			parser.position++
+			return values, nil
+		}
+		value, err := parser.value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		parser.skipSpace()
+		if parser.position < len(parser.text) && parser.peek() == ',' {
+			parser.position++
+			continue
+		}
+		if err := parser.expect(']'); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+}
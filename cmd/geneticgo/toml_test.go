@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	values, err := parseTOML(`
+# a comment
+geneSet = "01\t" # trailing comment
+numberOfChromosomes = 1_000
+
+[fitness]
+command = ['./evaluate', "--quick"]
+
+[solver]
+Selection = "tournament"
+LowerFitnessesAreBetter = true
+StagnationSeconds = 2.5
+
+[termination.limits]
+"max seconds" = 30
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"geneSet":             "01\t",
+		"numberOfChromosomes": int64(1000),
+		"fitness": map[string]interface{}{
+			"command": []interface{}{"./evaluate", "--quick"},
+		},
+		"solver": map[string]interface{}{
+			"Selection":               "tournament",
+			"LowerFitnessesAreBetter": true,
+			"StagnationSeconds":       2.5,
+		},
+		"termination": map[string]interface{}{
+			"limits": map[string]interface{}{"max seconds": int64(30)},
+		},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("got %#v, expected %#v", values, expected)
+	}
+}
+
+func TestParseTOMLReportsTheLine(t *testing.T) {
+	for _, text := range []string{
+		"a = 1\nb = \n",
+		"a = 1\nb = nope\n",
+		"a = 1\n[a]\n",
+		"a = 1\na = 2\n",
+		"a = 1\nb = [1, 2\n",
+		"a = 1\nb = \"open\n",
+	} {
+		_, err := parseTOML(text)
+		if err == nil || err.Error()[:7] != "line 2:" {
+			t.Errorf("%q: expected an error on line 2, got %v", text, err)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package genetic
+
+import (
+	"sync"
+	"time"
+)
+
+// Stop asks the runs in progress to finish. GetBest and
+// GetBestUsingHillClimbing return the best sequence found so far shortly
+// afterward.
+func (solver *Solver) Stop() {
+	solver.lock.Lock()
+	for run := range solver.runs {
+		run.stopping.stop()
+	}
+	solver.lock.Unlock()
+	solver.pause.resume()
+}
+
+// Pause suspends the runs in progress until Resume or Stop is called.
+// Evaluations already under way finish, and time spent paused does not
+// count toward MaxSecondsToRunWithoutImprovement.
+func (solver *Solver) Pause() {
+	solver.pause.pause()
+}
+
+// Resume continues the paused runs.
+func (solver *Solver) Resume() {
+	solver.pause.resume()
+}
+
+// Paused reports whether the solver's runs are paused.
+func (solver *Solver) Paused() bool {
+	return solver.pause.isPaused()
+}
+
+// stopGate is closed by stop, and opened again for each run.
+type stopGate struct {
+	lock    sync.Mutex
+	stopped chan struct{}
+}
+
+func (gate *stopGate) reset() {
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+	gate.stopped = make(chan struct{})
+}
+
+func (gate *stopGate) stop() {
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+	if gate.stopped != nil && !isClosed(gate.stopped) {
+		close(gate.stopped)
+	}
+}
+
+// done returns a channel that is closed once the run is stopped.
+func (gate *stopGate) done() <-chan struct{} {
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+	return gate.stopped
+}
+
+func isClosed(channel <-chan struct{}) bool {
+	select {
+	case <-channel:
+		return true
+	default:
+		return false
+	}
+}
+
+type pauseGate struct {
+	lock    sync.Mutex
+	resumed chan struct{} // nil unless paused
+	paused  chan struct{} // closed by pause, replaced by resume
+}
+
+func (gate *pauseGate) pause() {
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+	if gate.resumed == nil {
+		gate.resumed = make(chan struct{})
+		if gate.paused == nil {
+			gate.paused = make(chan struct{})
+		}
+		close(gate.paused)
+	}
+}
+
+func (gate *pauseGate) resume() {
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+	if gate.resumed != nil {
+		close(gate.resumed)
+		gate.resumed = nil
+		gate.paused = nil
+	}
+}
+
+// pausing returns a channel that is closed once the run is paused.
+func (gate *pauseGate) pausing() <-chan struct{} {
+	if gate == nil {
+		return nil
+	}
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+	if gate.paused == nil {
+		gate.paused = make(chan struct{})
+	}
+	return gate.paused
+}
+
+func (gate *pauseGate) isPaused() bool {
+	gate.lock.Lock()
+	defer gate.lock.Unlock()
+	return gate.resumed != nil
+}
+
+// wait blocks while paused and returns how long it waited.
+func (gate *pauseGate) wait() time.Duration {
+	if gate == nil {
+		return 0
+	}
+	gate.lock.Lock()
+	resumed := gate.resumed
+	gate.lock.Unlock()
+	if resumed == nil {
+		return 0
+	}
+	start := time.Now()
+	<-resumed
+	return time.Since(start)
+}
@@ -0,0 +1,103 @@
+package genetic
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPauseHoldsTheRunUntilResumed(t *testing.T) {
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = .2
+
+	finished := make(chan string)
+	go func() {
+		finished <- solver.GetBest(func(candidate string) int {
+			return -strings.Count(candidate, "1") // never reaches an optimum
+		}, func(string) {}, "01", 100, 1)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	solver.Pause()
+	if !solver.Paused() {
+		t.Fatal("expected the solver to report being paused")
+	}
+	time.Sleep(50 * time.Millisecond)
+	evaluations := solver.Statistics().Evaluations
+
+	select {
+	case <-finished:
+		t.Fatal("expected a paused run not to finish")
+	case <-time.After(400 * time.Millisecond):
+	}
+	if paused := solver.Statistics().Evaluations - evaluations; paused > 100 {
+		t.Errorf("expected evaluation to stop while paused, got %d more", paused)
+	}
+
+	solver.Resume()
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the run to finish after resuming")
+	}
+}
+
+func TestStopEndsAPausedRun(t *testing.T) {
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = 60
+
+	finished := make(chan string)
+	go func() {
+		finished <- solver.GetBest(func(candidate string) int {
+			return -strings.Count(candidate, "1")
+		}, func(string) {}, "01", 100, 1)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	solver.Pause()
+	solver.Stop()
+	select {
+	case best := <-finished:
+		if len(best) != 100 {
+			t.Errorf("expected the best so far, got %q", best)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Stop to end the run")
+	}
+}
+
+func TestStopEndsARunPromptly(t *testing.T) {
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = 60
+
+	finished := make(chan string)
+	go func() {
+		finished <- solver.GetBest(func(candidate string) int {
+			return -strings.Count(candidate, "1")
+		}, func(string) {}, "01", 100, 1)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	stopped := time.Now()
+	solver.Stop()
+	select {
+	case <-finished:
+		if elapsed := time.Since(stopped); elapsed > 250*time.Millisecond {
+			t.Errorf("expected Stop to end the run promptly, took %v", elapsed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Stop to end the run")
+	}
+}
+
+func TestRunWithoutImprovementEndsOnTime(t *testing.T) {
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = .1
+
+	start := time.Now()
+	solver.GetBest(func(candidate string) int { return 0 }, func(string) {}, "01", 10, 1)
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed > time.Second {
+		t.Errorf("expected the run to end about 100ms after its last improvement, took %v", elapsed)
+	}
+}
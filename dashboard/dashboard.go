@@ -0,0 +1,193 @@
+// Package dashboard serves a live web view of a run: best fitness over
+// time per evolver, each strategy's share of the improvements and a
+// rendering of the current best, streamed to the browser with
+// server-sent events. The page has no external assets.
+//
+//	board := dashboard.New("TSP", func(genes string) string {
+//		return ?? // an HTML or SVG picture of the genes, e.g. the route
+//	})
+//	solver.Observers = append(solver.Observers, board)
+//	http.Handle("/", board)
+//	go http.ListenAndServe(":8080", nil)
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"html"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// historyLimit caps the evolver improvements kept for newly connected
+// browsers.
+const historyLimit = 10000
+
+// Dashboard is a genetic.Observer and the http.Handler serving its page.
+// Requests for a path ending in /events receive the event stream, all
+// others the page, so it can be mounted under any prefix.
+type Dashboard struct {
+	title  string
+	render func(genes string) string
+
+	lock       sync.Mutex
+	history    []improvement
+	strategies map[string]int
+	best       *improvement
+	clients    map[chan []byte]bool
+}
+
+type improvement struct {
+	Elapsed   float64 `json:"elapsed"`
+	Evolver   int     `json:"evolver"`
+	Strategy  string  `json:"strategy"`
+	Fitness   int     `json:"fitness"`
+	Rendering string  `json:"rendering,omitempty"`
+}
+
+type state struct {
+	History    []improvement  `json:"history"`
+	Strategies map[string]int `json:"strategies"`
+	Best       *improvement   `json:"best"`
+}
+
+// New creates a dashboard. render turns the best genes into the HTML or
+// SVG shown beside the charts; when nil the genes are shown as text.
+func New(title string, render func(genes string) string) *Dashboard {
+	if render == nil {
+		render = func(genes string) string {
+			return "<pre>" + html.EscapeString(genes) + "</pre>"
+		}
+	}
+	return &Dashboard{
+		title:      title,
+		render:     render,
+		strategies: make(map[string]int),
+		clients:    make(map[chan []byte]bool),
+	}
+}
+
+// Observe records improvements and forwards them to connected browsers.
+// Each evolver's own improvements are charted, the run's improvements
+// are credited to their strategy and shown as the current best.
+func (dashboard *Dashboard) Observe(event genetic.Event) {
+	switch event.Kind {
+	case genetic.EvolverImprovementEvent:
+		dashboard.observeEvolverImprovement(event)
+	case genetic.ImprovementEvent:
+		dashboard.observeImprovement(event)
+	}
+}
+
+func (dashboard *Dashboard) observeEvolverImprovement(event genetic.Event) {
+	point := improvement{
+		Elapsed:  event.Elapsed.Seconds(),
+		Evolver:  event.Evolver,
+		Strategy: event.Strategy,
+		Fitness:  event.Fitness,
+	}
+
+	dashboard.lock.Lock()
+	defer dashboard.lock.Unlock()
+
+	if len(dashboard.history) == historyLimit {
+		dashboard.history = append(dashboard.history[:0], dashboard.history[1:]...)
+	}
+	dashboard.history = append(dashboard.history, point)
+
+	data, _ := json.Marshal(point)
+	dashboard.broadcast(message("point", data))
+}
+
+func (dashboard *Dashboard) observeImprovement(event genetic.Event) {
+	best := improvement{
+		Elapsed:   event.Elapsed.Seconds(),
+		Evolver:   event.Evolver,
+		Strategy:  event.Strategy,
+		Fitness:   event.Fitness,
+		Rendering: dashboard.render(event.Genes),
+	}
+
+	dashboard.lock.Lock()
+	defer dashboard.lock.Unlock()
+
+	dashboard.strategies[event.Strategy]++
+	dashboard.best = &best
+
+	data, _ := json.Marshal(best)
+	dashboard.broadcast(message("improvement", data))
+}
+
+// broadcast sends to each client, disconnecting any that have fallen
+// behind; browsers reconnect and are sent the full state.
+func (dashboard *Dashboard) broadcast(message []byte) {
+	for client := range dashboard.clients {
+		select {
+		case client <- message:
+		default:
+			delete(dashboard.clients, client)
+			close(client)
+		}
+	}
+}
+
+func message(event string, data []byte) []byte {
+	return []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event, data))
+}
+
+func (dashboard *Dashboard) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/events") {
+		dashboard.serveEvents(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, strings.Replace(page, "{{title}}", html.EscapeString(dashboard.title), -1))
+}
+
+func (dashboard *Dashboard) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	client := make(chan []byte, 64)
+	dashboard.lock.Lock()
+	data, _ := json.Marshal(state{
+		History:    dashboard.history,
+		Strategies: dashboard.strategies,
+		Best:       dashboard.best,
+	})
+	dashboard.clients[client] = true
+	dashboard.lock.Unlock()
+
+	defer func() {
+		dashboard.lock.Lock()
+		if dashboard.clients[client] {
+			delete(dashboard.clients, client)
+			close(client)
+		}
+		dashboard.lock.Unlock()
+	}()
+
+	w.Write(message("state", data))
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case message, ok := <-client:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(message); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package dashboard
+
+import (
+	"bufio"
+	"encoding/json"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServesPageWithoutExternalAssets(t *testing.T) {
+	board := New("<TSP>", nil)
+	server := httptest.NewServer(board)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	page := string(body)
+
+	if !strings.Contains(page, "<title>&lt;TSP&gt;</title>") {
+		t.Error("expected the escaped title")
+	}
+	if !strings.Contains(page, "EventSource") {
+		t.Error("expected the page to subscribe to events")
+	}
+	for _, external := range []string{"http://", "https://", "src="} {
+		if strings.Contains(page, external) {
+			t.Errorf("page refers to an external asset: %s", external)
+		}
+	}
+}
+
+func TestStreamsStateThenImprovements(t *testing.T) {
+	board := New("test", func(genes string) string { return "<svg>" + genes + "</svg>" })
+	board.Observe(genetic.Event{Kind: genetic.EvolverImprovementEvent, Evolver: 1, Strategy: "mutate", Fitness: 3, Genes: "abc"})
+	board.Observe(genetic.Event{Kind: genetic.ImprovementEvent, Evolver: 1, Strategy: "mutate", Fitness: 3, Genes: "abc"})
+	board.Observe(genetic.Event{Kind: genetic.PoolTruncatedEvent, Evolver: 1})
+
+	server := httptest.NewServer(board)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/run/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("expected an event stream, got %s", contentType)
+	}
+	events := readEvents(response.Body)
+
+	event, data := next(t, events)
+	if event != "state" {
+		t.Fatalf("expected the state first, got %s", event)
+	}
+	var initial state
+	if err := json.Unmarshal([]byte(data), &initial); err != nil {
+		t.Fatal(err)
+	}
+	if len(initial.History) != 1 || initial.Strategies["mutate"] != 1 || initial.Best.Rendering != "<svg>abc</svg>" {
+		t.Errorf("unexpected state %s", data)
+	}
+
+	// evolver 2 improves on itself without beating the run's best
+	board.Observe(genetic.Event{Kind: genetic.EvolverImprovementEvent, Evolver: 2, Strategy: "swap", Fitness: 2, Genes: "bbd"})
+
+	event, data = next(t, events)
+	var point improvement
+	if err := json.Unmarshal([]byte(data), &point); err != nil {
+		t.Fatal(err)
+	}
+	if event != "point" || point.Evolver != 2 || point.Fitness != 2 || point.Rendering != "" {
+		t.Errorf("unexpected %s %s", event, data)
+	}
+
+	board.Observe(genetic.Event{Kind: genetic.ImprovementEvent, Evolver: 2, Strategy: "crossover", Fitness: 4, Genes: "abd"})
+
+	event, data = next(t, events)
+	var best improvement
+	if err := json.Unmarshal([]byte(data), &best); err != nil {
+		t.Fatal(err)
+	}
+	if event != "improvement" || best.Evolver != 2 || best.Fitness != 4 || best.Rendering != "<svg>abd</svg>" {
+		t.Errorf("unexpected %s %s", event, data)
+	}
+}
+
+type sentEvent struct{ event, data string }
+
+func readEvents(r io.Reader) <-chan sentEvent {
+	events := make(chan sentEvent)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 1<<20)
+		var current sentEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				current.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				current.data = strings.TrimPrefix(line, "data: ")
+			case line == "":
+				events <- current
+				current = sentEvent{}
+			}
+		}
+	}()
+	return events
+}
+
+func next(t *testing.T, events <-chan sentEvent) (string, string) {
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("stream ended")
+		}
+		return e.event, e.data
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return "", ""
+}
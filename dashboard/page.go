@@ -0,0 +1,129 @@
+package dashboard
+
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{title}}</title>
+<style>
+body { font-family: sans-serif; margin: 1em 2em; color: #222; }
+h1 { font-size: 1.4em; }
+.panels { display: flex; flex-wrap: wrap; gap: 2em; }
+.panel { flex: 1 1 30em; }
+#chart { width: 100%; height: 20em; border: 1px solid #ccc; }
+#chart text { font-size: 11px; fill: #555; }
+.bar { display: flex; align-items: center; margin: 2px 0; }
+.bar span { width: 8em; font-family: monospace; }
+.bar div { background: #4a7bd0; height: 1em; margin-right: 0.5em; }
+#status { color: #888; }
+#best { overflow: auto; }
+</style>
+</head>
+<body>
+<h1>{{title}}</h1>
+<p id="status">connecting</p>
+<div class="panels">
+  <div class="panel">
+    <h2>Best fitness by evolver</h2>
+    <svg id="chart" viewBox="0 0 600 300" preserveAspectRatio="none"></svg>
+  </div>
+  <div class="panel">
+    <h2>Strategy success</h2>
+    <div id="strategies"></div>
+  </div>
+  <div class="panel">
+    <h2>Current best <span id="fitness"></span></h2>
+    <div id="best"></div>
+  </div>
+</div>
+<script>
+var colors = ["#4a7bd0", "#d0624a", "#4ab07b", "#b04ab0", "#c0a030", "#30a0c0", "#805030", "#606060"];
+var points = [];
+var strategies = {};
+
+function escape(text) {
+  return String(text).replace(/[&<>"]/g, function (c) {
+    return {"&": "&amp;", "<": "&lt;", ">": "&gt;", "\"": "&quot;"}[c];
+  });
+}
+
+function drawChart() {
+  var chart = document.getElementById("chart");
+  if (points.length == 0) {
+    chart.innerHTML = "";
+    return;
+  }
+  var minX = 0, maxX = points[points.length - 1].elapsed || 1;
+  var minY = Infinity, maxY = -Infinity;
+  points.forEach(function (p) {
+    minY = Math.min(minY, p.fitness);
+    maxY = Math.max(maxY, p.fitness);
+  });
+  if (minY == maxY) { minY -= 1; maxY += 1; }
+  var x = function (v) { return 40 + 550 * (v - minX) / (maxX - minX); };
+  var y = function (v) { return 285 - 270 * (v - minY) / (maxY - minY); };
+
+  var lines = {};
+  points.forEach(function (p) {
+    var line = lines[p.evolver] || (lines[p.evolver] = []);
+    if (line.length > 0) {
+      line.push(x(p.elapsed) + "," + line[line.length - 1].split(",")[1]);
+    }
+    line.push(x(p.elapsed) + "," + y(p.fitness));
+  });
+  var svg = '<line x1="40" y1="285" x2="590" y2="285" stroke="#999"/>' +
+    '<line x1="40" y1="15" x2="40" y2="285" stroke="#999"/>' +
+    '<text x="2" y="20">' + maxY + '</text><text x="2" y="285">' + minY + '</text>' +
+    '<text x="560" y="298">' + maxX.toFixed(1) + 's</text>';
+  Object.keys(lines).forEach(function (evolver, i) {
+    svg += '<polyline fill="none" stroke-width="2" stroke="' + colors[i % colors.length] +
+      '" points="' + lines[evolver].join(" ") + '"><title>evolver ' + evolver + '</title></polyline>';
+  });
+  chart.innerHTML = svg;
+}
+
+function drawStrategies() {
+  var total = 0;
+  Object.keys(strategies).forEach(function (name) { total += strategies[name]; });
+  var names = Object.keys(strategies).sort(function (a, b) { return strategies[b] - strategies[a]; });
+  document.getElementById("strategies").innerHTML = names.map(function (name) {
+    var percent = Math.round(100 * strategies[name] / total);
+    return '<div class="bar"><span>' + escape(name) + '</span><div style="width:' + (2 * percent) +
+      'px"></div>' + percent + '% (' + strategies[name] + ')</div>';
+  }).join("");
+}
+
+function showBest(best) {
+  if (!best) return;
+  document.getElementById("fitness").textContent = "fitness " + best.fitness + " at " + best.elapsed.toFixed(2) + "s";
+  document.getElementById("best").innerHTML = best.rendering;
+}
+
+var base = location.pathname.replace(/\/?$/, "/");
+var source = new EventSource(base + "events");
+source.addEventListener("state", function (e) {
+  var state = JSON.parse(e.data);
+  points = state.history || [];
+  strategies = state.strategies || {};
+  drawChart();
+  drawStrategies();
+  showBest(state.best);
+  document.getElementById("status").textContent = "live";
+});
+source.addEventListener("point", function (e) {
+  points.push(JSON.parse(e.data));
+  drawChart();
+});
+source.addEventListener("improvement", function (e) {
+  var best = JSON.parse(e.data);
+  strategies[best.strategy] = (strategies[best.strategy] || 0) + 1;
+  drawStrategies();
+  showBest(best);
+});
+source.onerror = function () {
+  document.getElementById("status").textContent = "disconnected, retrying";
+};
+</script>
+</body>
+</html>
+`
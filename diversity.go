@@ -0,0 +1,277 @@
+package genetic
+
+// ReplacementPolicy decides which pool member, if any, a new candidate
+// displaces once the pool is full.
+type ReplacementPolicy int
+
+const (
+	// ReplaceWorst keeps the best candidates seen regardless of similarity.
+	ReplaceWorst ReplacementPolicy = iota
+	// DeterministicCrowding makes a child compete only with its parent, or
+	// with the most similar pool member if the parent has left the pool.
+	DeterministicCrowding
+	// FitnessSharing ranks candidates by fitness rank multiplied by the
+	// number of neighbours within NicheRadius and displaces the member with
+	// the worst shared rank.
+	FitnessSharing
+	// Clearing lets at most NicheCapacity candidates survive within
+	// NicheRadius of each other; a newcomer to a full niche must beat the
+	// worst member of that niche.
+	Clearing
+)
+
+var replacementPolicyNames = []string{"replace-worst", "deterministic-crowding", "fitness-sharing", "clearing"}
+
+func (policy ReplacementPolicy) String() string {
+	return enumName(replacementPolicyNames, int(policy))
+}
+
+func (policy ReplacementPolicy) MarshalText() ([]byte, error) {
+	return []byte(policy.String()), nil
+}
+
+func (policy *ReplacementPolicy) UnmarshalText(text []byte) error {
+	value, err := parseEnumName(replacementPolicyNames, "ReplacementPolicy", string(text))
+	*policy = ReplacementPolicy(value)
+	return err
+}
+
+type nicheSettings struct {
+	policy   ReplacementPolicy
+	distance func(a, b []byte) int
+	radius   int
+	capacity int
+}
+
+// HammingDistance counts the positions at which a and b differ, treating
+// each gene beyond the end of the shorter sequence as a difference.
+func HammingDistance(a, b string) int {
+	return hammingDistance(a, b)
+}
+
+func hammingDistance[Genes string | []byte](a, b Genes) int {
+	shorter, longer := sort(len(a), len(b))
+	distance := longer - shorter
+	for i := 0; i < shorter; i++ {
+		if a[i] != b[i] {
+			distance++
+		}
+	}
+	return distance
+}
+
+// distanceBetweenGenes measures the distance between genomes with
+// distance, HammingDistance when it is nil.
+func distanceBetweenGenes(distance func(a, b string) int) func(a, b []byte) int {
+	if distance == nil {
+		return hammingDistance[[]byte]
+	}
+	return func(a, b []byte) int {
+		return distance(string(a), string(b))
+	}
+}
+
+func (niches *nicheSettings) radiusFor(item *sequenceInfo) int {
+	if niches.radius > 0 {
+		return niches.radius
+	}
+	return max(1, len(item.genes)/10)
+}
+
+func (p *pool) add(newItem *sequenceInfo) {
+	p.items = append(p.items, newItem)
+	insertionSort(p.items, p.childFitnessIsSameOrBetter, len(p.items)-1)
+	if p.niches != nil && p.niches.policy == FitnessSharing {
+		p.addToNicheCounts(newItem)
+	}
+}
+
+func (p *pool) removeAt(index int) {
+	removed := p.items[index]
+	copy(p.items[index:], p.items[index+1:])
+	p.items[len(p.items)-1] = nil
+	p.items = p.items[:len(p.items)-1]
+	if p.niches != nil && p.niches.policy == FitnessSharing {
+		p.removeFromNicheCounts(removed)
+	}
+}
+
+func (p *pool) addWithNiching(newItem *sequenceInfo) bool {
+	switch p.niches.policy {
+	case DeterministicCrowding:
+		return p.addWithCrowding(newItem)
+	case FitnessSharing:
+		return p.addWithSharing(newItem)
+	case Clearing:
+		return p.addWithClearing(newItem)
+	}
+	return false
+}
+
+func (p *pool) addWithCrowding(newItem *sequenceInfo) bool {
+	if len(p.items) < p.maxPoolSize {
+		p.add(newItem)
+		return true
+	}
+
+	competitor := -1
+	for i, item := range p.items {
+		if item == newItem.parent {
+			competitor = i
+			break
+		}
+	}
+	if competitor == -1 {
+		competitor = p.indexOfNearest(newItem)
+	}
+
+	if !p.childFitnessIsSameOrBetter(newItem, p.items[competitor]) {
+		return false
+	}
+	p.removeAt(competitor)
+	p.add(newItem)
+	return true
+}
+
+func (p *pool) addWithSharing(newItem *sequenceInfo) bool {
+	if len(p.items) < p.maxPoolSize {
+		p.add(newItem)
+		return true
+	}
+
+	radius := p.niches.radiusFor(newItem)
+	newItemNicheCount := 1.0
+	for _, item := range p.items {
+		newItemNicheCount += sharing(p.niches.distance(newItem.genes, item.genes), radius)
+	}
+	newItemRank := 1 + len(p.items)
+	for i, item := range p.items {
+		if p.childFitnessIsSameOrBetter(newItem, item) {
+			newItemRank = 1 + i
+			break
+		}
+	}
+
+	victim := -1
+	worstSharedRank := float64(newItemRank) * newItemNicheCount
+	for i, item := range p.items {
+		if i == 0 {
+			continue // never share away the best
+		}
+		sharedRank := float64(1+i) * p.nicheCounts[item]
+		if sharedRank > worstSharedRank {
+			victim, worstSharedRank = i, sharedRank
+		}
+	}
+	if victim == -1 {
+		return false
+	}
+	p.removeAt(victim)
+	p.add(newItem)
+	return true
+}
+
+func (p *pool) addWithClearing(newItem *sequenceInfo) bool {
+	radius := p.niches.radiusFor(newItem)
+	capacity := max(1, p.niches.capacity)
+
+	worstInNiche := -1
+	numberInNiche := 0
+	for i, item := range p.items {
+		if p.niches.distance(newItem.genes, item.genes) < radius {
+			numberInNiche++
+			worstInNiche = i // items are ordered best to worst
+		}
+	}
+
+	if numberInNiche >= capacity {
+		if !p.childFitnessIsSameOrBetter(newItem, p.items[worstInNiche]) {
+			return false
+		}
+		p.removeAt(worstInNiche)
+	} else if len(p.items) >= p.maxPoolSize {
+		// the worst item is also the best when it is the only one
+		if len(p.items) == 1 && !p.childFitnessIsSameOrBetter(newItem, p.items[0]) {
+			return false
+		}
+		p.removeAt(len(p.items) - 1)
+	}
+	p.add(newItem)
+	return true
+}
+
+func (p *pool) indexOfNearest(newItem *sequenceInfo) int {
+	nearest, nearestDistance := 0, -1
+	for i, item := range p.items {
+		distance := p.niches.distance(newItem.genes, item.genes)
+		if nearestDistance == -1 || distance < nearestDistance {
+			nearest, nearestDistance = i, distance
+		}
+	}
+	return nearest
+}
+
+func (p *pool) addToNicheCounts(newItem *sequenceInfo) {
+	radius := p.niches.radiusFor(newItem)
+	count := 1.0
+	for _, item := range p.items {
+		if item == newItem {
+			continue
+		}
+		share := sharing(p.niches.distance(newItem.genes, item.genes), radius)
+		p.nicheCounts[item] += share
+		count += share
+	}
+	p.nicheCounts[newItem] = count
+}
+
+func (p *pool) removeFromNicheCounts(removed *sequenceInfo) {
+	radius := p.niches.radiusFor(removed)
+	for _, item := range p.items {
+		p.nicheCounts[item] -= sharing(p.niches.distance(removed.genes, item.genes), radius)
+	}
+	delete(p.nicheCounts, removed)
+}
+
+func (p *pool) resetNicheCounts() {
+	if p.niches == nil || p.niches.policy != FitnessSharing {
+		return
+	}
+	p.nicheCounts = make(map[*sequenceInfo]float64, p.maxPoolSize)
+	items := p.items
+	for i, item := range items {
+		p.items = items[:i]
+		p.addToNicheCounts(item)
+	}
+	p.items = items
+}
+
+// diversity returns the mean distance between pool members, measured
+// over an evenly spaced sample of at most 50 of them.
+func (p *pool) diversity(distance func(a, b []byte) int) float64 {
+	items := p.snapshot()
+	step := max(1, len(items)/50)
+	sample := make([]*sequenceInfo, 0, 50)
+	for i := 0; i < len(items) && len(sample) < 50; i += step {
+		sample = append(sample, items[i])
+	}
+	if len(sample) < 2 {
+		return 0
+	}
+
+	total, pairs := 0, 0
+	for i := 0; i < len(sample); i++ {
+		for j := i + 1; j < len(sample); j++ {
+			total += distance(sample[i].genes, sample[j].genes)
+			pairs++
+		}
+	}
+	return float64(total) / float64(pairs)
+}
+
+func sharing(distance, radius int) float64 {
+	if distance >= radius {
+		return 0
+	}
+	return 1 - float64(distance)/float64(radius)
+}
@@ -41,20 +41,110 @@
 //     numberOfGenesInAChromosome := 1 // you decide
 // 	
 //     solver.NumberOfConcurrentEvolvers = 4 // you decide, defaults to 1
-//     solver.MaxProcs // you decide, defaults to 1
+//     solver.MaxProcs = 4 // fitness evaluations at once per run, you decide, defaults to no limit
+//     solver.RandomSeed = 42 // you decide, defaults to unseeded
+//     solver.Logger = slog.Default() // you decide, the solver is silent by default
+//     solver.PrintDiagnosticInfo = true // log improvements, restarts and finishes, with a histogram of pool lengths
+//     solver.PrintStrategyUsage = true // log strategy usage at the end of the run
 //
 // if your problem can be solved with a fixed number of genes:
 // 
 //     numberOfChromosomes := 10 // you decide
 //     var result = solver.GetBest(getFitness, display, geneSet, numberOfChromosomes, numberOfGenesInAChromosome)
 // 
+// or, if the length of a good sequence is unknown but bounded, let it vary within the bounds.
+// The initial pool mixes lengths, the add and remove strategies change them, and the shorter of two equally fit sequences wins:
+//
+//     var result = solver.GetBestOfVariableLength(getFitness, display, geneSet, minNumberOfChromosomes, maxNumberOfChromosomes, numberOfGenesInAChromosome)
+//
 // alternatively, if you want the gene sequence to grow as necessary:
 // 
 //     solver.MaxRoundsWithoutImprovement = 10 // you decide
 //     bestPossibleFitness := 0 // you decide
 //     maxNumberOfChromosomes := 50 // you decide
+//     solver.MinNumberOfChromosomes = 5 // start this long and never shrink below it, defaults to 1
 // 	
 //     var result = solver.GetBestUsingHillClimbing(getFitness, display, geneSet, maxNumberOfChromosomes, numberOfGenesInAChromosome, bestPossibleFitness)
 //
+// if you already have good candidates, such as nearest-neighbour tours or greedy packings, seed every evolver's pool with them.
+// Seeds with genes outside the gene set or the wrong number of chromosomes are logged and left out:
+//
+//     var result = solver.WithSeeds([]string{greedy, nearestNeighbour}).GetBest(getFitness, display, geneSet, numberOfChromosomes, numberOfGenesInAChromosome)
+//
+// if the best possible fitness is unknown, climb until longer sequences stop helping instead.
+// Better is higher, or lower if LowerFitnessesAreBetter, as with GetBest:
+//
+//     solver.MaxRoundsWithoutGrowthImprovement = 3 // you decide, defaults to MaxRoundsWithoutImprovement
+//     var result = solver.GetBestUsingOpenEndedHillClimbing(getFitness, display, geneSet, maxNumberOfChromosomes, numberOfGenesInAChromosome)
+//
+// hill climbing appends a new chromosome to grow a sequence. For program-like encodings, grow it anywhere instead, by one of these chosen at random:
+//
+//     solver.Growth = genetic.InsertChromosome | genetic.DuplicateChromosome // or genetic.AppendChromosome, genetic.TransplantChromosome from another pool member
+//
+// to keep such sequences from bloating, press for shorter ones in any of these ways:
+//
+//     solver.PreferShorter = true // hill climbing keeps the shorter of equally fit sequences, not only at bestPossibleFitness
+//     solver.LengthPenalty = 0.5 // fitness each chromosome costs when ranking sequences, bestPossibleFitness is judged without it
+//     solver.Selection = genetic.DoubleTournamentSelection // tournament entrants are the shorter of two members...
+//     solver.ParsimonyPercent = 70 // ...this often, defaults to 70
+//
+// if your fitness function can read the genes as bytes, GetBestBytes and GetBestBytesUsingHillClimbing save a copy of them per evaluation. The slice is only valid during the call and must not be changed:
+//
+//     getFitness := func(candidate []byte) int {
+//         return bytes.Count(candidate, []byte("1")) // you decide
+//     }
+//     var result = solver.GetBestBytes(getFitness, display, geneSet, numberOfChromosomes, numberOfGenesInAChromosome)
+//
+// if the pool converges on near-identical sequences, choose a diversity-preserving replacement policy:
+//
+//     solver.Replacement = genetic.DeterministicCrowding // or genetic.FitnessSharing, genetic.Clearing
+//     solver.Distance = genetic.HammingDistance // the default, you decide
+//     solver.NicheRadius = 5 // you decide, defaults to a tenth of the sequence length
+//     solver.NicheCapacity = 1 // Clearing only, you decide, defaults to 1
+//
+//     fmt.Println(solver.Diversity()) // mean distance between pool members at finish
+//
+// the pool size and the way parents are drawn from it can also be chosen:
+//
+//     solver.PoolSize = 200 // you decide, or set solver.PoolSizeFunc
+//     solver.Selection = genetic.TournamentSelection // or LinearRankSelection, RouletteSelection, TruncationSelection, DoubleTournamentSelection
+//     solver.TournamentSize = 3 // you decide, defaults to 2
+//     solver.TruncationPercent = 30 // you decide, defaults to 50
+//
+// when an evolver stalls it can shake up its pool before giving up:
+//
+//     solver.StagnationResponse = genetic.ReseedPool // or Hypermutate, RestartWithElite
+//     solver.StagnationSeconds = 5 // respond after this long without improvement, you decide
+//     solver.StagnationDiversity = 2 // and/or when solver.Distance between pool members drops below this, you decide
+//     solver.EliteCount = 5 // pool members left untouched, you decide, defaults to 1
+//     solver.ReseedPercent = 50 // ReseedPool only, you decide, defaults to 50
+//
+// for deceptive problems, rank the pool by novelty of behavior instead of fitness.
+// The best sequence by fitness is still tracked, displayed and returned:
+//
+//     solver.Behavior = func(genes string) []float64 {
+//     	return ?? // describe what the candidate does, e.g. where the mower ends up
+//     }
+//     solver.NoveltyNeighbors = 15 // k nearest behaviors averaged, defaults to 15
+//     solver.NoveltyArchiveThreshold = 2.5 // archive behaviors at least this novel, defaults to archiving every 100th
+//     solver.NoveltyArchiveSize = 1000 // you decide, defaults to 1000
+//     solver.NoveltyFitnessWeight = 0.1 // blend in fitness, defaults to 0 (pure novelty)
+//
+// to watch a long run, serve its statistics in the Prometheus text format:
+//
+//     http.Handle("/metrics", solver.MetricsHandler())
+//     go http.ListenAndServe(":8080", nil)
+//
+//     statistics := solver.Statistics() // or read them directly
+//
+// to compare runs offline, trace every improvement, pool truncation, restart and strategy summary:
+//
+//     solver.Observers = append(solver.Observers, genetic.NewTraceWriter(file, genetic.JSONLines)) // or genetic.CSV
+//
+// a configured solver can run several problems at the same time; Stop, Pause and Resume apply to all of them:
+//
+//     go func() { tours <- solver.GetBest(getTourFitness, display, cities, numberOfCities, 1) }()
+//     var packing = solver.GetBest(getPackingFitness, display, items, numberOfItems, 1)
+//
 // see the samples directory for specific examples
 package genetic
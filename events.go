@@ -0,0 +1,57 @@
+package genetic
+
+import (
+	"time"
+)
+
+// EventKind identifies what happened during a run.
+type EventKind string
+
+const (
+	// ImprovementEvent is a new best for the run, EvolverImprovementEvent
+	// a new best for one evolver, which need not be the run's.
+	ImprovementEvent        EventKind = "improvement"
+	EvolverImprovementEvent EventKind = "evolver-improvement"
+	PoolTruncatedEvent      EventKind = "pool-truncated"
+	ChildPoolResetEvent     EventKind = "child-pool-reset"
+	StagnationResponseEvent EventKind = "stagnation-response"
+	EvolverRestartedEvent   EventKind = "evolver-restarted"
+	StrategySummaryEvent    EventKind = "strategy-summary"
+)
+
+// Event describes something that happened during a run. Fields that do
+// not apply to the event's Kind are left at their zero value.
+type Event struct {
+	Time        time.Time
+	Elapsed     time.Duration
+	Evaluations int64
+	Kind        EventKind
+	Evolver     int
+	Strategy    string
+	Fitness     int
+	Genes       string
+	PoolSize    int
+	Selections  int
+	Successes   int
+}
+
+// Observer receives the solver's events. Evolvers run concurrently so
+// Observe must be safe for concurrent use.
+type Observer interface {
+	Observe(event Event)
+}
+
+func (solver *Solver) createEmitter(stats *runStatistics) func(Event) {
+	observers := solver.Observers
+	if len(observers) == 0 {
+		return func(Event) {}
+	}
+	return func(event Event) {
+		event.Time = time.Now()
+		event.Elapsed = event.Time.Sub(stats.start)
+		event.Evaluations = stats.evaluations.Load()
+		for _, observer := range observers {
+			observer.Observe(event)
+		}
+	}
+}
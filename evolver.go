@@ -1,6 +1,9 @@
 package genetic
 
 import (
+	"bytes"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -10,148 +13,192 @@ type evolver struct {
 	maxRoundsWithoutImprovement       int
 	lowerFitnessesAreBetter           bool
 	initialParent                     sequenceInfo
+	seeds                             []sequenceInfo // shared with the other evolvers
 	geneSet                           string
 	numberOfGenesPerChromosome        int
 	display                           chan *sequenceInfo
-	getFitness                        func(string) int
+	getFitness                        func([]byte) int
 
 	childFitnessIsBetter, childFitnessIsSameOrBetter func(child, other *sequenceInfo) bool
+	poolOrderIsBetter, poolOrderIsSameOrBetter       func(child, other *sequenceInfo) bool
+	novelty                                          *noveltySearch
+	improvements                                     chan *sequenceInfo
 
-	quit                     chan bool
-	nextGene, nextChromosome chan string
-	randomParent             chan *sequenceInfo
+	lifetime     *lifetime
+	genes        *geneGenerator
+	randomParent chan *sequenceInfo
 
 	strategies                     []strategyInfo
+	successLock                    sync.Mutex // guards the successes below
 	maxStrategySuccess             int
 	numberOfImprovements           int
 	successParentIsBestParentCount int
 
 	pool           *pool
 	maxPoolSize    int
-	random         randomSource
+	poolSize       func(numberOfChromosomes, numberOfGenesPerChromosome, numberOfGenes int) int
+	niches         *nicheSettings
+	selection      selectionSettings
+	growth         GrowthPolicy
+	stagnation     stagnationSettings
+	distance       func(a, b []byte) int
+	random         RandomSource
+	newRandom      func() RandomSource
 	isHillClimbing bool
+	stats          *runStatistics
+	emit           func(Event)
+	stopped        <-chan struct{}
+	pause          *pauseGate
+
+	// sequences are kept within these bounds, and vary in length between
+	// them in a fixed budget run when they differ
+	minNumberOfChromosomes, maxNumberOfChromosomes int
 }
 
-func (evolver *evolver) getBest(numberOfChromosomes int) {
+// getBest evolves sequences of between minNumberOfChromosomes and
+// maxNumberOfChromosomes until they stop improving.
+func (evolver *evolver) getBest(minNumberOfChromosomes, maxNumberOfChromosomes int) {
 	evolver.isHillClimbing = false
+	evolver.minNumberOfChromosomes, evolver.maxNumberOfChromosomes = minNumberOfChromosomes, maxNumberOfChromosomes
 	evolver.initialize()
-
-	defer func() {
-		evolver.quit <- true
-		<-evolver.nextChromosome
-		<-evolver.nextGene
-		for _, strategy := range evolver.strategies {
-			select {
-			case <-strategy.results:
-			default:
-			}
-		}
-	}()
+	numberOfChromosomes := maxNumberOfChromosomes
 
 	displayCaptureBest := make(chan *sequenceInfo)
+	evolver.improvements = displayCaptureBest
 
 	evolver.initializePool(numberOfChromosomes, displayCaptureBest)
 	evolver.initializeStrategies()
 	bestEver := evolver.initialParent
+	defer func() { evolver.finish(&bestEver) }()
 
-	go func() {
+	evolver.lifetime.start(func() {
 		for {
 			select {
-			case <-evolver.quit:
-				evolver.quit <- true
+			case <-evolver.lifetime.done:
 				return
 			case candidate := <-displayCaptureBest:
 				if !evolver.childFitnessIsBetter(candidate, &bestEver) {
 					continue
 				}
 				candidate.evolverId = evolver.id
-				go func() { evolver.display <- candidate }()
+				evolver.lifetime.send(evolver.display, candidate)
 
 				evolver.incrementStrategyUseCount(candidate, &bestEver)
 
 				bestEver = *candidate
+				evolver.recordBest(&bestEver)
 			}
 		}
-	}()
+	})
 
 	evolver.getBestWithInitialParent(numberOfChromosomes)
 }
 
-func (evolver *evolver) getBestUsingHillClimbing(maxNumberOfChromosomes, bestPossibleFitness int) {
+// getBestUsingHillClimbing grows the sequences from minNumberOfChromosomes
+// toward maxNumberOfChromosomes until isOptimal accepts the best fitness
+// or they stop improving. Growth ends early once it has not led to a
+// better sequence for maxRoundsWithoutGrowthImprovement rounds, unless
+// that is 0.
+func (evolver *evolver) getBestUsingHillClimbing(minNumberOfChromosomes, maxNumberOfChromosomes int, isOptimal func(fitness int) bool, maxRoundsWithoutGrowthImprovement int) {
 	evolver.isHillClimbing = true
+	evolver.minNumberOfChromosomes, evolver.maxNumberOfChromosomes = minNumberOfChromosomes, maxNumberOfChromosomes
 	evolver.initialize()
 
 	roundsSinceLastImprovement := 0
-	generationCount := 1
+	generationCount := minNumberOfChromosomes
 
 	filteredDisplay := make(chan *sequenceInfo)
+	evolver.improvements = filteredDisplay
 
 	evolver.initializePool(generationCount, filteredDisplay)
 	evolver.initializeStrategies()
 	bestEver := evolver.initialParent
+	defer func() { evolver.finish(&bestEver) }()
+
+	// the filter below updates bestEver and roundsSinceLastImprovement
+	// while the rounds run
+	var bestLock sync.Mutex
+	getBestEver := func() (sequenceInfo, int) {
+		bestLock.Lock()
+		defer bestLock.Unlock()
+		return bestEver, roundsSinceLastImprovement
+	}
 
-	go func() {
+	evolver.lifetime.start(func() {
 		for {
 			select {
-			case <-evolver.quit:
-				evolver.quit <- true
+			case <-evolver.lifetime.done:
 				return
 			case candidate := <-filteredDisplay:
+				bestLock.Lock()
 				if !evolver.childFitnessIsBetter(candidate, &bestEver) {
+					bestLock.Unlock()
 					continue
 				}
 				candidate.evolverId = evolver.id
-				go func() { evolver.display <- candidate }()
+				evolver.lifetime.send(evolver.display, candidate)
 				roundsSinceLastImprovement = 0
 
 				evolver.incrementStrategyUseCount(candidate, &bestEver)
 
 				bestEver = *candidate
+				evolver.recordBest(&bestEver)
+				bestLock.Unlock()
 			}
 		}
-	}()
-
-	defer func() {
-		evolver.quit <- true
-		go func() { evolver.quit <- true }()
-		go func() { evolver.quit <- true }()
-		<-evolver.nextChromosome
-		<-evolver.nextGene
-		for _, strategy := range evolver.strategies {
-			select {
-			case <-strategy.results:
-			default:
-			}
-		}
-	}()
+	})
 
 	maxLength := maxNumberOfChromosomes * evolver.numberOfGenesPerChromosome
 
-	for len(bestEver.genes) <= maxLength &&
-		roundsSinceLastImprovement < evolver.maxRoundsWithoutImprovement &&
-		bestEver.fitness != bestPossibleFitness &&
-		evolver.pool.any() {
+	// the best before the pool last grew, if the round that followed has
+	// yet to show whether growing helped
+	var bestBeforeGrowth *sequenceInfo
+	roundsWithoutGrowthImprovement := 0
 
-		roundsSinceLastImprovementBefore := roundsSinceLastImprovement
-		evolver.getBestWithInitialParent(len(bestEver.genes) / evolver.numberOfGenesPerChromosome)
+	for {
+		best, roundsSinceLastImprovementBefore := getBestEver()
+		if len(best.genes) > maxLength ||
+			roundsSinceLastImprovementBefore >= evolver.maxRoundsWithoutImprovement ||
+			isOptimal(best.fitness) ||
+			!evolver.pool.any() ||
+			evolver.isStopped() {
+			break
+		}
+
+		evolver.getBestWithInitialParent(len(best.genes) / evolver.numberOfGenesPerChromosome)
 
-		if bestEver.fitness == bestPossibleFitness {
+		best, _ = getBestEver()
+		if isOptimal(best.fitness) {
 			break
 		}
+		if bestBeforeGrowth != nil {
+			if len(best.genes) > len(bestBeforeGrowth.genes) && evolver.childFitnessIsBetter(&best, bestBeforeGrowth) {
+				roundsWithoutGrowthImprovement = 0
+			} else {
+				roundsWithoutGrowthImprovement++
+			}
+			bestBeforeGrowth = nil
+		}
+		bestLock.Lock()
 		if roundsSinceLastImprovementBefore == roundsSinceLastImprovement {
 			roundsSinceLastImprovement++
-			if roundsSinceLastImprovement >= evolver.maxRoundsWithoutImprovement {
-				break
-			}
+		}
+		outOfRounds := roundsSinceLastImprovement >= evolver.maxRoundsWithoutImprovement
+		bestLock.Unlock()
+		if outOfRounds {
+			break
 		}
 
 		generationCount++
 
-		if len(bestEver.genes) == maxLength {
+		if len(best.genes) == maxLength ||
+			maxRoundsWithoutGrowthImprovement > 0 && roundsWithoutGrowthImprovement >= maxRoundsWithoutGrowthImprovement {
 			continue
 		}
+		grownFrom := best
+		bestBeforeGrowth = &grownFrom
 
-		evolver.maxPoolSize = getMaxPoolSize(len(bestEver.genes)/evolver.numberOfGenesPerChromosome+1, evolver.numberOfGenesPerChromosome, len(evolver.geneSet))
+		evolver.maxPoolSize = evolver.getMaxPoolSize(len(best.genes)/evolver.numberOfGenesPerChromosome + 1)
 
 		newPool := make([]*sequenceInfo, 0, evolver.maxPoolSize)
 		distinctPool := make(map[string]bool, evolver.maxPoolSize)
@@ -159,28 +206,38 @@ func (evolver *evolver) getBestUsingHillClimbing(maxNumberOfChromosomes, bestPos
 		improved := false
 		climbStrategy := strategyInfo{name: "climb     "}
 
-		for round := 0; round < 100 && !improved; round++ {
-			for _, parent := range evolver.pool.items {
+		for round := 0; round < 100 && !improved && !evolver.isStopped(); round++ {
+			evolver.pause.wait()
+			parents := evolver.pool.snapshot()
+			for _, parent := range parents {
 				if len(parent.genes) >= maxLength {
 					continue
 				}
-				childGenes := parent.genes + <-evolver.nextChromosome
-				if distinctPool[childGenes] {
+				growth := evolver.growth.choose(evolver.random)
+				childGenes := make([]byte, 0, len(parent.genes)+evolver.numberOfGenesPerChromosome)
+				if growth == AppendChromosome {
+					childGenes = evolver.genes.appendChromosome(append(childGenes, parent.genes...))
+				} else {
+					donor := parents[evolver.random.Intn(len(parents))]
+					childGenes = growGenes(childGenes, parent.genes, donor.genes, growth,
+						evolver.genes.gene, evolver.numberOfGenesPerChromosome, evolver.random)
+				}
+				if distinctPool[string(childGenes)] {
 					continue
 				}
-				distinctPool[childGenes] = true
+				distinctPool[string(childGenes)] = true
 
-				fitness := evolver.getFitness(childGenes)
-				child := sequenceInfo{genes: childGenes, fitness: fitness, strategy: climbStrategy}
+				child := sequenceInfo{genes: childGenes, strategy: growth.strategy(climbStrategy)}
 				child.parent = parent
+				evolver.evaluate(&child)
 				if len(newPool) < evolver.maxPoolSize {
 					newPool = append(newPool, &child)
 				} else {
 					newPool[len(newPool)-1] = &child
 				}
-				insertionSort(newPool, evolver.childFitnessIsSameOrBetter, len(newPool)-1)
+				insertionSort(newPool, evolver.poolOrderIsSameOrBetter, len(newPool)-1)
 
-				if evolver.childFitnessIsBetter(&child, &bestEver) {
+				if evolver.childFitnessIsBetter(&child, &best) {
 					improved = true
 					filteredDisplay <- &child
 				}
@@ -188,101 +245,178 @@ func (evolver *evolver) getBestUsingHillClimbing(maxNumberOfChromosomes, bestPos
 		}
 
 		evolver.pool.truncateAndAddAll(newPool)
+		evolver.emit(Event{Kind: PoolTruncatedEvent, Evolver: evolver.id, PoolSize: evolver.pool.len()})
 	}
 }
 
 func (evolver *evolver) getBestWithInitialParent(numberOfChromosomes int) {
 
 	start := time.Now()
+	maxWithoutImprovement := time.Duration(evolver.maxSecondsToRunWithoutImprovement * float64(time.Second))
 
-	quit := make(chan bool)
+	// the children and the evaluations in flight end with the round,
+	// before the children join the pool
+	round := newLifetime()
 
 	children := NewPool(evolver.maxPoolSize,
-		quit,
-		evolver.childFitnessIsSameOrBetter,
+		round,
+		evolver.poolOrderIsSameOrBetter,
 		evolver.pool.addNewItem)
 	poolBest := evolver.pool.getBest()
-	children.addNewItem <- poolBest
-
-	timeout := make(chan bool, 1)
-	go func() {
-		for {
-			time.Sleep(1 * time.Millisecond)
-			select {
-			case timeout <- true:
-			case <-quit:
-				quit <- true
-				close(timeout)
-				return
-			}
+	children.addAll([]*sequenceInfo{poolBest})
+
+	// evaluations signal an improvement, and a child pool that may be
+	// ready to promote, without waiting for the loop to notice
+	improved := make(chan bool, 1)
+	promote := make(chan bool, 1)
+	signal := func(channel chan bool) {
+		select {
+		case channel <- true:
+		default:
 		}
-		close(timeout)
-	}()
+	}
 
 	defer func() {
-		quit <- true
-		evolver.pool.addAll(children.items)
+		round.stop()
+		evolver.pool.addAll(children.snapshot())
 	}()
 
+	lastStagnationResponse := time.Now()
+
+	// wake goes off when the next check is due: running out of time
+	// without an improvement, promoting a half-full child pool, or
+	// looking for stagnation
+	nextCheck := func() time.Duration {
+		now := time.Now()
+		due := start.Add(maxWithoutImprovement)
+		if halfway := start.Add(maxWithoutImprovement / 2); halfway.After(now) && halfway.Before(due) {
+			due = halfway
+		}
+		if evolver.stagnation.isEnabled() {
+			since := lastStagnationResponse
+			if since.Before(start) {
+				since = start
+			}
+			if stagnant := evolver.nextStagnationCheck(now, since); stagnant.Before(due) {
+				due = stagnant
+			}
+		}
+		return due.Sub(now)
+	}
+	wake := time.NewTimer(nextCheck())
+	defer wake.Stop()
+
+	promoteChildren := func() {
+		evolver.pool.truncateAndAddAll(children.snapshot())
+		evolver.emit(Event{Kind: PoolTruncatedEvent, Evolver: evolver.id, PoolSize: evolver.pool.len()})
+
+		bestParent := evolver.pool.getBest()
+		children.reset(bestParent)
+		children.addItem(bestParent)
+		evolver.emit(Event{Kind: ChildPoolResetEvent, Evolver: evolver.id, PoolSize: children.len()})
+	}
+	childrenAreReady := func() bool {
+		return children.len() >= 20 || children.len() >= 10 &&
+			time.Since(start) > maxWithoutImprovement/2
+	}
+
+	pausing := evolver.pause.pausing()
+
 	for {
+		evolver.successLock.Lock()
 		maxStrategySuccess := evolver.maxStrategySuccess
+		evolver.successLock.Unlock()
 		// prefer successful strategies
 		minStrategySuccess := evolver.random.Intn(maxStrategySuccess)
 		for index := 0; index < len(evolver.strategies); index++ {
-			if evolver.strategies[index].successCount < minStrategySuccess {
+			if evolver.getStrategySuccessCount(index) < minStrategySuccess {
 				continue
 			}
 			select {
 			case child := <-evolver.strategies[index].results:
+				evolver.strategies[index].selectionCount++
 				if evolver.pool.contains(child) {
+					evolver.stats.recordCacheHit()
+					discard(child)
 					continue
 				}
-				go func() {
-					child.fitness = evolver.getFitness(child.genes)
+				round.start(func() {
+					evolver.evaluate(child)
 
 					if !evolver.pool.any() {
+						evolver.discard(child)
 						return // already returned final result
 					}
 
 					poolWorst := evolver.pool.getWorst()
-					if !evolver.childFitnessIsSameOrBetter(child, poolWorst) {
+					if !evolver.poolOrderIsSameOrBetter(child, poolWorst) {
+						evolver.discard(child)
 						return
 					}
 
-					if child.fitness == poolWorst.fitness {
+					if evolver.isSameRank(child, poolWorst) {
 						evolver.pool.addItem(child)
 						return
 					}
 
 					children.addItem(child)
+					if children.len() >= 10 {
+						signal(promote)
+					}
 
 					poolBest := evolver.pool.getBest()
-					if evolver.childFitnessIsBetter(child, poolBest) {
+					if evolver.poolOrderIsBetter(child, poolBest) {
 						children.addItem(child.parent)
-						start = time.Now()
+						signal(improved)
 					}
-				}()
-			case <-timeout:
-				elapsedSeconds := time.Since(start).Seconds()
-				if elapsedSeconds >= evolver.maxSecondsToRunWithoutImprovement {
+				})
+			case <-improved:
+				start = time.Now()
+				resetTimer(wake, nextCheck())
+			case <-promote:
+				if childrenAreReady() {
+					promoteChildren()
+				}
+			case <-pausing:
+				paused := evolver.pause.wait()
+				start = start.Add(paused)
+				lastStagnationResponse = lastStagnationResponse.Add(paused)
+				pausing = evolver.pause.pausing()
+				resetTimer(wake, nextCheck())
+			case <-evolver.stopped:
+				return
+			case <-wake.C:
+				if time.Since(start) >= maxWithoutImprovement {
 					return
 				}
-				if children.len() >= 20 || children.len() >= 10 &&
-					elapsedSeconds > evolver.maxSecondsToRunWithoutImprovement/2 {
-					evolver.pool.truncateAndAddAll(children.items)
-
-					bestParent := evolver.pool.getBest()
-					children.reset(bestParent)
-					children.addItem(bestParent)
+				if evolver.stagnation.isEnabled() {
+					if lastStagnationResponse.Before(start) {
+						lastStagnationResponse = start
+					}
+					if evolver.isStagnant(time.Since(lastStagnationResponse)) {
+						evolver.respondToStagnation(numberOfChromosomes)
+						bestParent := evolver.pool.getBest()
+						children.reset(bestParent)
+						evolver.emit(Event{Kind: ChildPoolResetEvent, Evolver: evolver.id, PoolSize: children.len()})
+						lastStagnationResponse = time.Now()
+						wake.Reset(nextCheck())
+						continue
+					}
+				}
+				if childrenAreReady() {
+					promoteChildren()
 				}
+				wake.Reset(nextCheck())
 			}
 		}
 	}
 }
 
 func (evolver *evolver) incrementStrategyUseCount(candidate, bestEver *sequenceInfo) {
+	evolver.successLock.Lock()
+	defer evolver.successLock.Unlock()
 
-	if bestEver.genes == candidate.parent.genes {
+	if bytes.Equal(bestEver.genes, candidate.parent.genes) {
 		evolver.successParentIsBestParentCount++
 	}
 	evolver.numberOfImprovements++
@@ -294,67 +428,191 @@ func (evolver *evolver) incrementStrategyUseCount(candidate, bestEver *sequenceI
 	}
 }
 
+func (evolver *evolver) getStrategySuccessCount(index int) int {
+	evolver.successLock.Lock()
+	defer evolver.successLock.Unlock()
+	return evolver.strategies[index].successCount
+}
+
+func (evolver *evolver) evaluate(sequence *sequenceInfo) {
+	sequence.fitness = evolver.getFitness(sequence.genes)
+	if evolver.novelty == nil {
+		return
+	}
+	evolver.novelty.score(sequence, evolver.pool)
+	if evolver.novelty.isNewBest(sequence, evolver.childFitnessIsBetter) {
+		evolver.lifetime.send(evolver.improvements, sequence)
+	}
+}
+
+// discard recycles an evaluated child that was not kept, unless novelty
+// search, which can display children as they are scored, may have shown
+// it.
+func (evolver *evolver) discard(child *sequenceInfo) {
+	if evolver.novelty == nil {
+		discard(child)
+	}
+}
+
+func (evolver *evolver) createRandomNumberGenerator() RandomSource {
+	if evolver.newRandom == nil {
+		return createRandomNumberGenerator()
+	}
+	return evolver.newRandom()
+}
+
 func (evolver *evolver) initialize() {
 	evolver.maxStrategySuccess = initialStrategySuccess + 1
-	evolver.random = createRandomNumberGenerator()
-	evolver.initializeChannels(evolver.geneSet, evolver.numberOfGenesPerChromosome)
+	if evolver.novelty == nil {
+		evolver.poolOrderIsBetter = evolver.childFitnessIsBetter
+		evolver.poolOrderIsSameOrBetter = evolver.childFitnessIsSameOrBetter
+	} else {
+		evolver.poolOrderIsBetter, evolver.poolOrderIsSameOrBetter = createNoveltyComparisonFunctions()
+	}
+	evolver.random = evolver.createRandomNumberGenerator()
+	evolver.lifetime = newLifetime()
+	evolver.genes = evolver.newGeneGenerator()
 }
 
-func (evolver *evolver) initializeChannels(geneSet string, numberOfGenesPerChromosome int) {
-	evolver.quit = make(chan bool)
-	evolver.nextGene = make(chan string, 1+numberOfGenesPerChromosome)
-	go generateGene(evolver.nextGene, geneSet, evolver.quit)
+func (evolver *evolver) isStopped() bool {
+	return evolver.stopped != nil && isClosed(evolver.stopped)
+}
 
-	evolver.nextChromosome = make(chan string, 1)
-	go generateChromosome(evolver.nextChromosome, evolver.nextGene, geneSet, numberOfGenesPerChromosome, evolver.quit)
+// canChangeLength reports whether strategies may add and remove
+// chromosomes.
+func (evolver *evolver) canChangeLength() bool {
+	return evolver.isHillClimbing || evolver.minNumberOfChromosomes < evolver.maxNumberOfChromosomes
+}
+
+// randomNumberOfChromosomes returns the length of a new random sequence:
+// numberOfChromosomes, unless the length varies in a fixed budget run, in
+// which case any length within the bounds.
+func (evolver *evolver) randomNumberOfChromosomes(numberOfChromosomes int) int {
+	if evolver.isHillClimbing || evolver.minNumberOfChromosomes >= evolver.maxNumberOfChromosomes {
+		return numberOfChromosomes
+	}
+	return evolver.minNumberOfChromosomes + evolver.random.Intn(evolver.maxNumberOfChromosomes-evolver.minNumberOfChromosomes+1)
+}
+
+func (evolver *evolver) isSameRank(child, other *sequenceInfo) bool {
+	if evolver.novelty != nil {
+		return child.score == other.score
+	}
+	return child.fitness == other.fitness
+}
+
+// newGeneGenerator creates a generator for the calling goroutine alone.
+func (evolver *evolver) newGeneGenerator() *geneGenerator {
+	return newGeneGenerator(evolver.geneSet, evolver.numberOfGenesPerChromosome, evolver.createRandomNumberGenerator())
+}
+
+// finish stops the evolver's goroutines, then hands the best it found to
+// the solver. Improvements still on their way when it stopped are in the
+// pool, so nothing better than the solver's result is left behind or
+// displayed after the run ends.
+func (evolver *evolver) finish(bestEver *sequenceInfo) {
+	evolver.lifetime.stop()
+
+	best := bestEver
+	for _, item := range evolver.pool.snapshot() {
+		if evolver.childFitnessIsBetter(item, best) {
+			best = item
+		}
+	}
+	if best != bestEver {
+		evolver.recordBest(best)
+	}
+	candidate := *best
+	candidate.evolverId = evolver.id
+	evolver.display <- &candidate
+}
+
+// recordBest records a new best for this evolver and tells the observers.
+func (evolver *evolver) recordBest(best *sequenceInfo) {
+	evolver.stats.recordEvolverBest(evolver.id, best)
+	evolver.emit(Event{
+		Kind:     EvolverImprovementEvent,
+		Evolver:  evolver.id,
+		Strategy: strings.TrimSpace(best.strategy.name),
+		Fitness:  best.fitness,
+		Genes:    string(best.genes),
+	})
 }
 
 func (evolver *evolver) initializePool(numberOfChromosomes int, display chan *sequenceInfo) {
-	evolver.maxPoolSize = getMaxPoolSize(numberOfChromosomes, evolver.numberOfGenesPerChromosome, len(evolver.geneSet))
+	evolver.maxPoolSize = evolver.getMaxPoolSize(numberOfChromosomes)
 
-	evolver.pool = NewPool(evolver.maxPoolSize,
-		evolver.quit,
-		evolver.childFitnessIsSameOrBetter,
-		display)
+	evolver.pool = newPool(evolver.maxPoolSize,
+		evolver.lifetime,
+		evolver.poolOrderIsSameOrBetter,
+		display,
+		evolver.niches,
+		evolver.createRandomNumberGenerator())
+	evolver.stats.recordPool(evolver.id, evolver.pool)
 
 	if len(evolver.initialParent.genes) == 0 {
-		evolver.initialParent = sequenceInfo{genes: generateParent(evolver.nextChromosome, evolver.geneSet, numberOfChromosomes, evolver.numberOfGenesPerChromosome)}
-		evolver.initialParent.fitness = evolver.getFitness(evolver.initialParent.genes)
+		evolver.initialParent = sequenceInfo{genes: evolver.genes.parent(evolver.randomNumberOfChromosomes(numberOfChromosomes))}
+		evolver.evaluate(&evolver.initialParent)
 		evolver.initialParent.parent = &evolver.initialParent
+	} else if evolver.novelty != nil {
+		evolver.novelty.score(&evolver.initialParent, nil)
 	}
 
-	evolver.pool.populatePool(evolver.nextChromosome, evolver.geneSet, numberOfChromosomes, evolver.numberOfGenesPerChromosome, evolver.childFitnessIsBetter, evolver.getFitness, &evolver.initialParent)
+	evaluate := func(sequence *sequenceInfo) {
+		evolver.pause.wait()
+		evolver.evaluate(sequence)
+	}
+	seeds := make([]*sequenceInfo, len(evolver.seeds))
+	for i := range evolver.seeds {
+		seed := evolver.seeds[i]
+		seed.parent = &seed
+		if evolver.novelty != nil {
+			evolver.novelty.score(&seed, nil)
+		}
+		seeds[i] = &seed
+	}
+	newGenes := func() []byte {
+		return evolver.genes.parent(evolver.randomNumberOfChromosomes(numberOfChromosomes))
+	}
+	evolver.pool.populatePool(newGenes, evaluate, &evolver.initialParent, seeds)
 
 	evolver.numberOfImprovements = 1
 	evolver.randomParent = make(chan *sequenceInfo, 10)
-	go func() {
+	evolver.lifetime.start(func() {
 		rand := 0
 		for {
+			evolver.successLock.Lock()
 			numberOfImprovements := evolver.numberOfImprovements
+			successParentIsBestParentCount := evolver.successParentIsBestParentCount
+			evolver.successLock.Unlock()
 			select {
-			case <-evolver.quit:
-				evolver.quit <- true
+			case <-evolver.lifetime.done:
 				return
 			default:
 				rand = evolver.random.Intn(numberOfImprovements)
-				if rand <= evolver.successParentIsBestParentCount {
+				if rand <= successParentIsBestParentCount {
 					select {
-					case <-evolver.quit:
-						evolver.quit <- true
+					case <-evolver.lifetime.done:
 						return
 					case evolver.randomParent <- evolver.pool.getBest():
 					}
 				}
 
 				select {
-				case <-evolver.quit:
-					evolver.quit <- true
+				case <-evolver.lifetime.done:
 					return
-				case evolver.randomParent <- evolver.pool.getRandomItem():
+				case evolver.randomParent <- evolver.pool.selectItem(evolver.selection):
 				}
 			}
 		}
-	}()
+	})
+}
+
+func (evolver *evolver) getMaxPoolSize(numberOfChromosomes int) int {
+	if evolver.poolSize == nil {
+		return getMaxPoolSize(numberOfChromosomes, evolver.numberOfGenesPerChromosome, len(evolver.geneSet))
+	}
+	return max(1, evolver.poolSize(numberOfChromosomes, evolver.numberOfGenesPerChromosome, len(evolver.geneSet)))
 }
 
 func getMaxPoolSize(numberOfChromosomes, numberOfGenesPerChromosome, numberOfGenes int) int {
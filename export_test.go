@@ -0,0 +1,90 @@
+package genetic
+
+// TestPool gives the external tests access to a pool where higher
+// fitnesses are better. Its methods may be called from any goroutine.
+type TestPool struct{ pool *pool }
+
+// NewTestPool uses a seeded, goroutine-safe random source when random is
+// nil.
+func NewTestPool(maxPoolSize int, random RandomSource) TestPool {
+	if random == nil {
+		random = createSeededRandomNumberGenerators(1)()
+	}
+	isSameOrBetter := func(child, other *sequenceInfo) bool { return child.fitness >= other.fitness }
+	return TestPool{makePool(maxPoolSize, isSameOrBetter, nil, random)}
+}
+
+// NewTestNichePool is NewTestPool with a replacement policy that measures
+// distance with HammingDistance.
+func NewTestNichePool(maxPoolSize int, policy ReplacementPolicy, radius, capacity int) TestPool {
+	pool := NewTestPool(maxPoolSize, nil)
+	pool.pool.niches = &nicheSettings{
+		policy:   policy,
+		distance: hammingDistance[[]byte],
+		radius:   radius,
+		capacity: capacity,
+	}
+	return pool
+}
+
+func (p TestPool) Add(genes string, fitness int) (isNewBest bool) {
+	return p.pool.offer(&sequenceInfo{genes: []byte(genes), fitness: fitness})
+}
+
+// AddChild offers genes as a child of the pool member with parentGenes,
+// or of no pool member if there is none.
+func (p TestPool) AddChild(genes string, fitness int, parentGenes string) {
+	child := &sequenceInfo{genes: []byte(genes), fitness: fitness}
+	for _, item := range p.pool.snapshot() {
+		if string(item.genes) == parentGenes {
+			child.parent = item
+		}
+	}
+	p.pool.offer(child)
+}
+
+func (p TestPool) Best() string {
+	return string(p.pool.getBest().genes)
+}
+
+func (p TestPool) Contains(genes string) bool {
+	return p.pool.contains(&sequenceInfo{genes: []byte(genes)})
+}
+
+func (p TestPool) Fitnesses() []int {
+	items := p.pool.snapshot()
+	fitnesses := make([]int, 0, len(items))
+	for _, item := range items {
+		fitnesses = append(fitnesses, item.fitness)
+	}
+	return fitnesses
+}
+
+func (p TestPool) Genes() []string {
+	items := p.pool.snapshot()
+	genes := make([]string, 0, len(items))
+	for _, item := range items {
+		genes = append(genes, string(item.genes))
+	}
+	return genes
+}
+
+func (p TestPool) Select(scheme SelectionScheme, tournamentSize, truncationPercent int) string {
+	return string(p.pool.selectItem(selectionSettings{
+		scheme:            scheme,
+		tournamentSize:    tournamentSize,
+		truncationPercent: truncationPercent,
+	}).genes)
+}
+
+func (p TestPool) SelectParsimoniously(tournamentSize, parsimonyPercent int) string {
+	return string(p.pool.selectItem(selectionSettings{
+		scheme:           DoubleTournamentSelection,
+		tournamentSize:   tournamentSize,
+		parsimonyPercent: parsimonyPercent,
+	}).genes)
+}
+
+func (p TestPool) TruncateTo(length int) {
+	p.pool.truncate(length)
+}
@@ -0,0 +1,135 @@
+// Package fitness adapts fitness functions that live outside the program,
+// in a child process or behind an HTTP service, to the
+// func(genes string) int the solver expects.
+package fitness
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClosed is passed to OnError for candidates submitted after Close.
+var ErrClosed = errors.New("fitness: evaluator is closed")
+
+type request struct {
+	genes   string
+	fitness int
+	err     error
+	done    chan struct{}
+}
+
+func (r *request) finish(fitness int, err error) {
+	r.fitness, r.err = fitness, err
+	close(r.done)
+}
+
+func failAll(batch []*request, err error) {
+	for _, r := range batch {
+		r.finish(0, err)
+	}
+}
+
+// batching gathers concurrent evaluations into batches and hands them to
+// a fixed number of workers.
+type batching struct {
+	lock     sync.RWMutex
+	closed   bool
+	requests chan *request
+	workers  sync.WaitGroup
+}
+
+// evaluate starts the workers on first use and waits for the fitness of
+// the genes.
+func (b *batching) evaluate(genes string, size int, delay time.Duration, workers int, work func(batches <-chan []*request)) (int, error) {
+	b.lock.RLock()
+	if b.requests == nil && !b.closed {
+		b.lock.RUnlock()
+		b.start(size, delay, workers, work)
+		b.lock.RLock()
+	}
+	if b.closed {
+		b.lock.RUnlock()
+		return 0, ErrClosed
+	}
+	r := &request{genes: genes, done: make(chan struct{})}
+	b.requests <- r
+	b.lock.RUnlock()
+
+	<-r.done
+	return r.fitness, r.err
+}
+
+func (b *batching) start(size int, delay time.Duration, workers int, work func(batches <-chan []*request)) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.requests != nil || b.closed {
+		return
+	}
+	b.requests = make(chan *request)
+	batches := make(chan []*request)
+	go collectBatches(b.requests, batches, max(1, size), delay)
+	for i := 0; i < max(1, workers); i++ {
+		b.workers.Add(1)
+		go func() {
+			defer b.workers.Done()
+			work(batches)
+		}()
+	}
+}
+
+// close finishes the outstanding batches then stops the workers.
+func (b *batching) close() {
+	b.lock.Lock()
+	alreadyClosed := b.closed
+	b.closed = true
+	b.lock.Unlock()
+	if alreadyClosed || b.requests == nil {
+		return
+	}
+	close(b.requests)
+	b.workers.Wait()
+}
+
+// collectBatches sends batches of at most size requests, waiting up to
+// delay after the first request for the rest of a batch to arrive.
+func collectBatches(requests <-chan *request, batches chan<- []*request, size int, delay time.Duration) {
+	defer close(batches)
+	for first := range requests {
+		batch := []*request{first}
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if delay > 0 {
+			timer = time.NewTimer(delay)
+			timeout = timer.C
+		}
+	fill:
+		for len(batch) < size {
+			if timeout == nil {
+				select {
+				case r, ok := <-requests:
+					if !ok {
+						break fill
+					}
+					batch = append(batch, r)
+				default:
+					break fill
+				}
+				continue
+			}
+			select {
+			case r, ok := <-requests:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, r)
+			case <-timeout:
+				break fill
+			}
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		batches <- batch
+	}
+}
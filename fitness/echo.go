@@ -0,0 +1,66 @@
+package fitness
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCrash is returned by ServeEcho when asked to crash.
+var ErrCrash = errors.New("fitness: echo evaluator asked to crash")
+
+// ServeEcho is the reference evaluator for the child process protocols.
+// It reads candidates from r and writes their fitnesses to w until r is
+// exhausted:
+//
+//   - genes that are a decimal integer, e.g. "42", have that fitness
+//   - "sleep:N" is answered with N after sleeping N milliseconds
+//   - "crash" is not answered; ServeEcho returns ErrCrash instead
+//   - any other genes have their length as their fitness
+//
+// cmd/echo-evaluator serves it on standard input and output, which makes
+// it a stand-in evaluator for tests and a template for writing your own.
+func ServeEcho(r io.Reader, w io.Writer, protocol Protocol) error {
+	reader := bufio.NewReader(r)
+	writer := bufio.NewWriter(w)
+	for {
+		genes, err := protocol.readRequest(reader)
+		if err == io.EOF {
+			return writer.Flush()
+		}
+		if err != nil {
+			return err
+		}
+
+		fitness := echoFitness(genes)
+		if genes == "crash" {
+			writer.Flush()
+			return ErrCrash
+		}
+		if err := protocol.writeResponse(writer, fitness); err != nil {
+			return err
+		}
+		// answer as soon as the batch we were sent has been read
+		if reader.Buffered() == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func echoFitness(genes string) int {
+	if value, err := strconv.Atoi(genes); err == nil {
+		return value
+	}
+	if delay, found := strings.CutPrefix(genes, "sleep:"); found {
+		if milliseconds, err := strconv.Atoi(delay); err == nil {
+			time.Sleep(time.Duration(milliseconds) * time.Millisecond)
+			return milliseconds
+		}
+	}
+	return len(genes)
+}
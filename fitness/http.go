@@ -0,0 +1,234 @@
+package fitness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is passed to OnError for candidates that were not sent
+// because the service has been failing.
+var ErrCircuitOpen = errors.New("fitness: circuit open, service is failing")
+
+// HTTP evaluates candidates by POSTing batches of them to a service:
+//
+//	{"candidates": ["genes", ...]}
+//
+// which answers with their fitnesses in the same order:
+//
+//	{"fitnesses": [42, ...]}
+//
+// Failed requests, 5xx and 429 responses are retried with exponential
+// backoff. After BreakerThreshold batches in a row have failed, the
+// circuit opens and candidates are given OnError's fitness without
+// being sent until BreakerCooldown has passed, when a single batch is
+// let through to test the service.
+type HTTP struct {
+	URL              string
+	Client           *http.Client  // defaults to one keeping MaxInFlight connections alive
+	Header           http.Header   // added to every request
+	BatchSize        int           // candidates per request, defaults to 1
+	BatchDelay       time.Duration // how long to wait for a batch to fill, defaults to not waiting
+	MaxInFlight      int           // concurrent requests, defaults to 4
+	Timeout          time.Duration // limit on each attempt, defaults to 30 seconds
+	Retries          int           // attempts after the first, defaults to none
+	RetryDelay       time.Duration // wait before the first retry, doubling each time, defaults to 100ms
+	BreakerThreshold int           // failed batches in a row that open the circuit, defaults to 5
+	BreakerCooldown  time.Duration // how long the circuit stays open, defaults to 10 seconds
+
+	// OnError decides the fitness of a candidate that could not be
+	// evaluated. By default it is -1, which the solver treats as invalid
+	// when hill climbing.
+	OnError func(genes string, err error) int
+
+	batching   batching
+	clientOnce sync.Once
+	client     *http.Client
+	breaker    circuitBreaker
+}
+
+type httpRequest struct {
+	Candidates []string `json:"candidates"`
+}
+
+type httpResponse struct {
+	Fitnesses []int `json:"fitnesses"`
+}
+
+// GetFitness has the signature the solver expects and may be called
+// concurrently.
+func (service *HTTP) GetFitness(genes string) int {
+	inFlight := service.MaxInFlight
+	if inFlight < 1 {
+		inFlight = 4
+	}
+	fitness, err := service.batching.evaluate(genes, service.BatchSize, service.BatchDelay, inFlight, service.work)
+	if err != nil {
+		if service.OnError != nil {
+			return service.OnError(genes, err)
+		}
+		return -1
+	}
+	return fitness
+}
+
+// Close waits for outstanding evaluations then releases idle
+// connections.
+func (service *HTTP) Close() error {
+	service.batching.close()
+	if service.client != nil {
+		service.client.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (service *HTTP) getClient() *http.Client {
+	service.clientOnce.Do(func() {
+		service.client = service.Client
+		if service.client != nil {
+			return
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.MaxIdleConnsPerHost = max(4, service.MaxInFlight)
+		service.client = &http.Client{Transport: transport}
+	})
+	return service.client
+}
+
+func (service *HTTP) work(batches <-chan []*request) {
+	for batch := range batches {
+		if !service.breaker.allow(service.BreakerThreshold, service.BreakerCooldown) {
+			failAll(batch, ErrCircuitOpen)
+			continue
+		}
+		fitnesses, err := service.post(batch)
+		service.breaker.record(err, service.BreakerThreshold, service.BreakerCooldown)
+		if err != nil {
+			failAll(batch, err)
+			continue
+		}
+		for i, r := range batch {
+			r.finish(fitnesses[i], nil)
+		}
+	}
+}
+
+// post sends the batch, retrying failures that might be temporary.
+func (service *HTTP) post(batch []*request) ([]int, error) {
+	body := httpRequest{Candidates: make([]string, len(batch))}
+	for i, r := range batch {
+		body.Candidates[i] = r.genes
+	}
+	content, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	delay := service.RetryDelay
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	for attempt := 0; ; attempt++ {
+		fitnesses, retry, err := service.attempt(content, len(batch))
+		if err == nil || !retry || attempt >= service.Retries {
+			return fitnesses, err
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+func (service *HTTP) attempt(content []byte, count int) (fitnesses []int, retry bool, err error) {
+	timeout := service.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, service.URL, bytes.NewReader(content))
+	if err != nil {
+		return nil, false, err
+	}
+	for name, values := range service.Header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := service.getClient().Do(request)
+	if err != nil {
+		return nil, true, fmt.Errorf("fitness: %v", err)
+	}
+	defer func() {
+		// drain so the connection can be reused
+		io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		retry = response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+		return nil, retry, fmt.Errorf("fitness: %s answered %s", service.URL, response.Status)
+	}
+	var decoded httpResponse
+	if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+		return nil, false, fmt.Errorf("fitness: decoding response from %s: %v", service.URL, err)
+	}
+	if len(decoded.Fitnesses) != count {
+		return nil, false, fmt.Errorf("fitness: %s answered %d fitnesses for %d candidates", service.URL, len(decoded.Fitnesses), count)
+	}
+	return decoded.Fitnesses, false, nil
+}
+
+type circuitBreaker struct {
+	lock      sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// allow reports whether a batch may be sent. Once the cooldown has passed
+// a single trial batch is allowed per cooldown.
+func (breaker *circuitBreaker) allow(threshold int, cooldown time.Duration) bool {
+	breaker.lock.Lock()
+	defer breaker.lock.Unlock()
+	if breaker.failures < breakerThreshold(threshold) {
+		return true
+	}
+	now := time.Now()
+	if now.Before(breaker.openUntil) {
+		return false
+	}
+	breaker.openUntil = now.Add(breakerCooldown(cooldown))
+	return true
+}
+
+func (breaker *circuitBreaker) record(err error, threshold int, cooldown time.Duration) {
+	breaker.lock.Lock()
+	defer breaker.lock.Unlock()
+	if err == nil {
+		breaker.failures = 0
+		return
+	}
+	breaker.failures++
+	if breaker.failures >= breakerThreshold(threshold) {
+		breaker.openUntil = time.Now().Add(breakerCooldown(cooldown))
+	}
+}
+
+func breakerThreshold(threshold int) int {
+	if threshold < 1 {
+		return 5
+	}
+	return threshold
+}
+
+func breakerCooldown(cooldown time.Duration) time.Duration {
+	if cooldown <= 0 {
+		return 10 * time.Second
+	}
+	return cooldown
+}
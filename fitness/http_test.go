@@ -0,0 +1,183 @@
+package fitness
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// echoService answers like ServeEcho, one POST of candidates at a time.
+type echoService struct {
+	requests    atomic.Int64
+	candidates  atomic.Int64
+	inFlight    atomic.Int64
+	maxInFlight atomic.Int64
+	connections atomic.Int64
+	failures    atomic.Int64 // requests still to answer with 503
+	delay       time.Duration
+}
+
+func (service *echoService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	service.requests.Add(1)
+	inFlight := service.inFlight.Add(1)
+	defer service.inFlight.Add(-1)
+	for {
+		highest := service.maxInFlight.Load()
+		if inFlight <= highest || service.maxInFlight.CompareAndSwap(highest, inFlight) {
+			break
+		}
+	}
+
+	if service.failures.Add(-1) >= 0 {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	var request httpRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	service.candidates.Add(int64(len(request.Candidates)))
+	time.Sleep(service.delay)
+
+	var response httpResponse
+	for _, genes := range request.Candidates {
+		response.Fitnesses = append(response.Fitnesses, echoFitness(genes))
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func startEchoService(t *testing.T) (*echoService, *httptest.Server) {
+	service := &echoService{}
+	server := httptest.NewUnstartedServer(service)
+	server.Config.ConnState = func(connection net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			service.connections.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return service, server
+}
+
+func TestHTTPBatchesConcurrentCandidates(t *testing.T) {
+	service, server := startEchoService(t)
+	evaluator := &HTTP{URL: server.URL, BatchSize: 10, BatchDelay: 20 * time.Millisecond, MaxInFlight: 2}
+	defer evaluator.Close()
+
+	var wait sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			if fitness := evaluator.GetFitness(strconv.Itoa(i)); fitness != i {
+				t.Errorf("expected %d, got %d", i, fitness)
+			}
+		}(i)
+	}
+	wait.Wait()
+
+	if service.candidates.Load() != 100 {
+		t.Errorf("expected 100 candidates, got %d", service.candidates.Load())
+	}
+	if service.requests.Load() >= 100 {
+		t.Errorf("expected batched requests, got %d", service.requests.Load())
+	}
+}
+
+func TestHTTPLimitsRequestsInFlight(t *testing.T) {
+	service, server := startEchoService(t)
+	service.delay = 10 * time.Millisecond
+	evaluator := &HTTP{URL: server.URL, MaxInFlight: 3}
+	defer evaluator.Close()
+
+	var wait sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			evaluator.GetFitness("abc")
+		}()
+	}
+	wait.Wait()
+
+	if service.maxInFlight.Load() > 3 {
+		t.Errorf("expected at most 3 requests in flight, saw %d", service.maxInFlight.Load())
+	}
+}
+
+func TestHTTPReusesConnections(t *testing.T) {
+	service, server := startEchoService(t)
+	evaluator := &HTTP{URL: server.URL, MaxInFlight: 1}
+	defer evaluator.Close()
+
+	for i := 0; i < 20; i++ {
+		evaluator.GetFitness("abc")
+	}
+	if service.connections.Load() != 1 {
+		t.Errorf("expected 1 connection, got %d", service.connections.Load())
+	}
+}
+
+func TestHTTPRetriesWithBackoff(t *testing.T) {
+	service, server := startEchoService(t)
+	service.failures.Store(2)
+	evaluator := &HTTP{URL: server.URL, Retries: 2, RetryDelay: 10 * time.Millisecond}
+	defer evaluator.Close()
+
+	start := time.Now()
+	if fitness := evaluator.GetFitness("7"); fitness != 7 {
+		t.Errorf("expected 7, got %d", fitness)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected to back off 10ms then 20ms, took %v", elapsed)
+	}
+	if service.requests.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", service.requests.Load())
+	}
+}
+
+func TestHTTPCircuitBreaker(t *testing.T) {
+	service, server := startEchoService(t)
+	service.failures.Store(1000)
+	var failure error
+	evaluator := &HTTP{
+		URL:              server.URL,
+		MaxInFlight:      1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+		OnError: func(genes string, err error) int {
+			failure = err
+			return -100
+		},
+	}
+	defer evaluator.Close()
+
+	for i := 0; i < 10; i++ {
+		if fitness := evaluator.GetFitness("7"); fitness != -100 {
+			t.Fatalf("expected OnError's fitness, got %d", fitness)
+		}
+	}
+	if !errors.Is(failure, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", failure)
+	}
+	if service.requests.Load() != 2 {
+		t.Errorf("expected the circuit to open after 2 requests, got %d", service.requests.Load())
+	}
+
+	service.failures.Store(0)
+	time.Sleep(60 * time.Millisecond)
+	if fitness := evaluator.GetFitness("7"); fitness != 7 {
+		t.Errorf("expected the circuit to close again, got %d", fitness)
+	}
+	if fitness := evaluator.GetFitness("8"); fitness != 8 {
+		t.Errorf("expected 8, got %d", fitness)
+	}
+}
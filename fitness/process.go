@@ -0,0 +1,223 @@
+package fitness
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync/atomic"
+	"time"
+)
+
+// Process evaluates candidates with a pool of long-lived child processes
+// speaking Protocol on their standard input and output.
+//
+//	evaluator := &fitness.Process{Command: []string{"python3", "model.py"}, Processes: 4, BatchSize: 16}
+//	defer evaluator.Close()
+//	best := solver.GetBest(evaluator.GetFitness, display, geneSet, 10, 1)
+//
+// The children are started on first use. A child that crashes, answers
+// garbage or exceeds Timeout is killed and replaced before its next batch.
+type Process struct {
+	Command    []string
+	Env        []string  // defaults to the current environment
+	Dir        string    // defaults to the current directory
+	Stderr     io.Writer // where the children's standard error goes, defaults to discarding it
+	Protocol   Protocol
+	Processes  int           // children evaluating in parallel, defaults to 1
+	BatchSize  int           // candidates sent to a child at once, defaults to 1
+	BatchDelay time.Duration // how long to wait for a batch to fill, defaults to not waiting
+	Timeout    time.Duration // limit on each batch exchange with a child, defaults to none
+
+	// OnError decides the fitness of a candidate that could not be
+	// evaluated. By default it is -1, which the solver treats as invalid
+	// when hill climbing.
+	OnError func(genes string, err error) int
+
+	batching batching
+	restarts atomic.Int64
+}
+
+// GetFitness has the signature the solver expects and may be called
+// concurrently.
+func (process *Process) GetFitness(genes string) int {
+	fitness, err := process.batching.evaluate(genes, process.BatchSize, process.BatchDelay, process.Processes, process.work)
+	if err != nil {
+		if process.OnError != nil {
+			return process.OnError(genes, err)
+		}
+		return -1
+	}
+	return fitness
+}
+
+// Restarts returns the number of children that were replaced after a
+// failure.
+func (process *Process) Restarts() int64 {
+	return process.restarts.Load()
+}
+
+// Close waits for outstanding evaluations then stops the children.
+func (process *Process) Close() error {
+	process.batching.close()
+	return nil
+}
+
+type child struct {
+	command *exec.Cmd
+	input   io.WriteCloser
+	writer  *bufio.Writer
+	reader  *bufio.Reader
+}
+
+func (process *Process) startChild() (*child, error) {
+	if len(process.Command) == 0 {
+		return nil, fmt.Errorf("fitness: Process has no Command")
+	}
+	command := exec.Command(process.Command[0], process.Command[1:]...)
+	command.Env = process.Env
+	command.Dir = process.Dir
+	command.Stderr = process.Stderr
+	input, err := command.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	output, err := command.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := command.Start(); err != nil {
+		return nil, err
+	}
+	return &child{
+		command: command,
+		input:   input,
+		writer:  bufio.NewWriter(input),
+		reader:  bufio.NewReader(output),
+	}, nil
+}
+
+func (c *child) kill() {
+	c.command.Process.Kill()
+	c.input.Close()
+	c.command.Wait()
+}
+
+// stop asks the child to exit by closing its input, killing it if it
+// takes more than a second.
+func (c *child) stop() {
+	c.input.Close()
+	exited := make(chan struct{})
+	go func() {
+		c.command.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		c.command.Process.Kill()
+		<-exited
+	}
+}
+
+func (process *Process) work(batches <-chan []*request) {
+	var c *child
+	defer func() {
+		if c != nil {
+			c.stop()
+		}
+	}()
+
+	for batch := range batches {
+		// genes the protocol cannot send fail on their own, leaving the
+		// child and the rest of the batch alone
+		batch = process.leaveOutUnsendable(batch)
+		if len(batch) == 0 {
+			continue
+		}
+		if c == nil {
+			var err error
+			if c, err = process.startChild(); err != nil {
+				failAll(batch, err)
+				continue
+			}
+		}
+
+		fitnesses, err := process.exchange(c, batch)
+		if err != nil {
+			c.kill()
+			c = nil
+			process.restarts.Add(1)
+			failAll(batch, err)
+			continue
+		}
+		for i, r := range batch {
+			r.finish(fitnesses[i], nil)
+		}
+	}
+}
+
+// leaveOutUnsendable fails the requests whose genes the protocol cannot
+// send and returns the others.
+func (process *Process) leaveOutUnsendable(batch []*request) []*request {
+	sendable := batch[:0]
+	for _, r := range batch {
+		if err := process.Protocol.check(r.genes); err != nil {
+			r.finish(0, err)
+			continue
+		}
+		sendable = append(sendable, r)
+	}
+	return sendable
+}
+
+// exchange sends the batch to the child and reads back its fitnesses.
+func (process *Process) exchange(c *child, batch []*request) ([]int, error) {
+	written := make(chan error, 1)
+	go func() {
+		for _, r := range batch {
+			if err := process.Protocol.writeRequest(c.writer, r.genes); err != nil {
+				written <- err
+				return
+			}
+		}
+		written <- c.writer.Flush()
+	}()
+
+	read := make(chan error, 1)
+	fitnesses := make([]int, len(batch))
+	go func() {
+		for i := range batch {
+			fitness, err := process.Protocol.readResponse(c.reader)
+			if err != nil {
+				read <- err
+				return
+			}
+			fitnesses[i] = fitness
+		}
+		read <- nil
+	}()
+
+	var timeout <-chan time.Time
+	if process.Timeout > 0 {
+		timer := time.NewTimer(process.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for pending := 2; pending > 0; pending-- {
+		select {
+		case err := <-written:
+			if err != nil {
+				return nil, fmt.Errorf("fitness: writing to %s: %v", process.Command[0], err)
+			}
+		case err := <-read:
+			if err != nil {
+				return nil, fmt.Errorf("fitness: reading from %s: %v", process.Command[0], err)
+			}
+		case <-timeout:
+			return nil, fmt.Errorf("fitness: %s took longer than %v", process.Command[0], process.Timeout)
+		}
+	}
+	return fitnesses, nil
+}
@@ -0,0 +1,192 @@
+package fitness
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestMain lets the test binary stand in for cmd/echo-evaluator.
+func TestMain(m *testing.M) {
+	if name := os.Getenv("FITNESS_ECHO_EVALUATOR"); name != "" {
+		var protocol Protocol
+		if err := protocol.UnmarshalText([]byte(name)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if err := ServeEcho(os.Stdin, os.Stdout, protocol); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func newEchoProcess(protocol Protocol) *Process {
+	return &Process{
+		Command:  []string{os.Args[0]},
+		Env:      append(os.Environ(), "FITNESS_ECHO_EVALUATOR="+protocol.String()),
+		Protocol: protocol,
+	}
+}
+
+func TestServeEcho(t *testing.T) {
+	var output bytes.Buffer
+	err := ServeEcho(strings.NewReader("42\nabc\nsleep:1\n-3"), &output, LineProtocol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != "42\n3\n1\n-3\n" {
+		t.Errorf("got %q", output.String())
+	}
+}
+
+func TestServeEchoCrash(t *testing.T) {
+	var output bytes.Buffer
+	err := ServeEcho(strings.NewReader("1\ncrash\n2\n"), &output, LineProtocol)
+	if err != ErrCrash {
+		t.Errorf("expected ErrCrash, got %v", err)
+	}
+	if output.String() != "1\n" {
+		t.Errorf("got %q", output.String())
+	}
+}
+
+func TestProcessProtocols(t *testing.T) {
+	for _, protocol := range []Protocol{LineProtocol, LengthPrefixedProtocol} {
+		t.Run(protocol.String(), func(t *testing.T) {
+			process := newEchoProcess(protocol)
+			defer process.Close()
+
+			expected := map[string]int{"42": 42, "-7": -7, "abcd": 4}
+			if protocol == LengthPrefixedProtocol {
+				expected["a\nb"] = 3
+			}
+			for genes, fitness := range expected {
+				if actual := process.GetFitness(genes); actual != fitness {
+					t.Errorf("%q: expected %d, got %d", genes, fitness, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessEvaluatesConcurrentCandidatesInBatches(t *testing.T) {
+	process := newEchoProcess(LineProtocol)
+	process.Processes = 3
+	process.BatchSize = 8
+	process.BatchDelay = 5 * time.Millisecond
+	defer process.Close()
+
+	var wait sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			if fitness := process.GetFitness(strconv.Itoa(i)); fitness != i {
+				t.Errorf("expected %d, got %d", i, fitness)
+			}
+		}(i)
+	}
+	wait.Wait()
+	if process.Restarts() != 0 {
+		t.Errorf("expected no restarts, got %d", process.Restarts())
+	}
+}
+
+func TestProcessRestartsACrashedChild(t *testing.T) {
+	process := newEchoProcess(LineProtocol)
+	var failure error
+	process.OnError = func(genes string, err error) int {
+		failure = err
+		return -100
+	}
+	defer process.Close()
+
+	if fitness := process.GetFitness("crash"); fitness != -100 || failure == nil {
+		t.Errorf("expected OnError's fitness, got %d and error %v", fitness, failure)
+	}
+	if fitness := process.GetFitness("5"); fitness != 5 {
+		t.Errorf("expected the replacement child to answer 5, got %d", fitness)
+	}
+	if process.Restarts() != 1 {
+		t.Errorf("expected 1 restart, got %d", process.Restarts())
+	}
+}
+
+func TestProcessFailsUnsendableGenesWithoutRestartingTheChild(t *testing.T) {
+	process := newEchoProcess(LineProtocol)
+	process.BatchSize = 3
+	process.BatchDelay = 50 * time.Millisecond
+	var failure error
+	process.OnError = func(genes string, err error) int {
+		failure = err
+		return -100
+	}
+	defer process.Close()
+
+	fitnesses := make(map[string]int)
+	var lock sync.Mutex
+	var wait sync.WaitGroup
+	for _, genes := range []string{"1", "a\nb", "3"} {
+		wait.Add(1)
+		go func(genes string) {
+			defer wait.Done()
+			fitness := process.GetFitness(genes)
+			lock.Lock()
+			fitnesses[genes] = fitness
+			lock.Unlock()
+		}(genes)
+	}
+	wait.Wait()
+
+	if expected := map[string]int{"1": 1, "a\nb": -100, "3": 3}; !reflect.DeepEqual(fitnesses, expected) {
+		t.Errorf("expected %v, got %v", expected, fitnesses)
+	}
+	if failure == nil || !strings.Contains(failure.Error(), "line break") {
+		t.Errorf("expected a line break error, got %v", failure)
+	}
+	if process.Restarts() != 0 {
+		t.Errorf("expected no restarts, got %d", process.Restarts())
+	}
+}
+
+func TestProcessTimeout(t *testing.T) {
+	process := newEchoProcess(LineProtocol)
+	process.Timeout = 50 * time.Millisecond
+	defer process.Close()
+
+	start := time.Now()
+	if fitness := process.GetFitness("sleep:5000"); fitness != -1 {
+		t.Errorf("expected -1, got %d", fitness)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("timeout took %v", elapsed)
+	}
+	if fitness := process.GetFitness("sleep:1"); fitness != 1 {
+		t.Errorf("expected 1, got %d", fitness)
+	}
+}
+
+func TestProcessAfterClose(t *testing.T) {
+	process := newEchoProcess(LineProtocol)
+	process.GetFitness("1")
+	process.Close()
+
+	var failure error
+	process.OnError = func(genes string, err error) int {
+		failure = err
+		return -1
+	}
+	process.GetFitness("1")
+	if !errors.Is(failure, ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", failure)
+	}
+}
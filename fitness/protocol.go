@@ -0,0 +1,134 @@
+package fitness
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Protocol is how candidates and fitnesses are framed on a child
+// process's standard input and output. A batch is simply several requests
+// written back to back, answered by as many responses in the same order,
+// so the child never needs to know about batching.
+type Protocol int
+
+const (
+	// LineProtocol sends each candidate's genes followed by a newline and
+	// reads each fitness back as a line holding a decimal integer. The
+	// genes must not contain a newline.
+	LineProtocol Protocol = iota
+	// LengthPrefixedProtocol sends each candidate as a 4-byte big-endian
+	// length followed by that many bytes of genes and reads each fitness
+	// back as a 4-byte big-endian signed integer.
+	LengthPrefixedProtocol
+)
+
+var protocolNames = []string{"line", "length-prefixed"}
+
+func (protocol Protocol) String() string {
+	if protocol < 0 || int(protocol) >= len(protocolNames) {
+		return fmt.Sprintf("Protocol(%d)", int(protocol))
+	}
+	return protocolNames[protocol]
+}
+
+func (protocol Protocol) MarshalText() ([]byte, error) {
+	return []byte(protocol.String()), nil
+}
+
+func (protocol *Protocol) UnmarshalText(text []byte) error {
+	for i, name := range protocolNames {
+		if strings.EqualFold(name, string(text)) {
+			*protocol = Protocol(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown Protocol %q, expected one of %v", text, protocolNames)
+}
+
+// check reports genes the protocol cannot frame.
+func (protocol Protocol) check(genes string) error {
+	if protocol == LineProtocol && strings.ContainsAny(genes, "\r\n") {
+		return fmt.Errorf("fitness: genes %q contain a line break", genes)
+	}
+	return nil
+}
+
+func (protocol Protocol) writeRequest(w *bufio.Writer, genes string) error {
+	if protocol == LengthPrefixedProtocol {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(genes)))
+		if _, err := w.Write(length[:]); err != nil {
+			return err
+		}
+		_, err := w.WriteString(genes)
+		return err
+	}
+	if err := protocol.check(genes); err != nil {
+		return err
+	}
+	_, err := w.WriteString(genes + "\n")
+	return err
+}
+
+func (protocol Protocol) readRequest(r *bufio.Reader) (string, error) {
+	if protocol == LengthPrefixedProtocol {
+		var length [4]byte
+		if _, err := io.ReadFull(r, length[:]); err != nil {
+			return "", err
+		}
+		genes := make([]byte, binary.BigEndian.Uint32(length[:]))
+		if _, err := io.ReadFull(r, genes); err != nil {
+			return "", unexpected(err)
+		}
+		return string(genes), nil
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		if err == io.EOF && line != "" {
+			return line, nil
+		}
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (protocol Protocol) writeResponse(w *bufio.Writer, fitness int) error {
+	if protocol == LengthPrefixedProtocol {
+		var value [4]byte
+		binary.BigEndian.PutUint32(value[:], uint32(int32(fitness)))
+		_, err := w.Write(value[:])
+		return err
+	}
+	_, err := w.WriteString(strconv.Itoa(fitness) + "\n")
+	return err
+}
+
+func (protocol Protocol) readResponse(r *bufio.Reader) (int, error) {
+	if protocol == LengthPrefixedProtocol {
+		var value [4]byte
+		if _, err := io.ReadFull(r, value[:]); err != nil {
+			return 0, unexpected(err)
+		}
+		return int(int32(binary.BigEndian.Uint32(value[:]))), nil
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return 0, unexpected(err)
+	}
+	fitness, err := strconv.Atoi(strings.TrimSpace(line))
+	if err != nil {
+		return 0, fmt.Errorf("fitness %q is not an integer", strings.TrimSpace(line))
+	}
+	return fitness, nil
+}
+
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
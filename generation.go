@@ -1,56 +1,64 @@
 package genetic
 
-import (
-	"bytes"
-)
+// geneGenerator draws random genes for a single goroutine. Each draw from
+// random is a number below len(geneSet)^genesPerDraw whose digits pick
+// the next genesPerDraw genes, so most genes cost a slice read.
+type geneGenerator struct {
+	geneSet                    string
+	numberOfGenesPerChromosome int
+	random                     RandomSource
+	genesPerDraw, drawRange    int
+	batch                      []int
+}
 
-func generateChromosome(nextChromosome, nextGene chan string, geneSet string, numberOfGenesPerChromosome int, quit chan bool) {
-	defer func() { close(nextChromosome) }()
+// maxDrawRange keeps draws within a 32-bit int.
+const maxDrawRange = 1 << 30
 
-	for {
-		c := bytes.NewBuffer(make([]byte, 0, numberOfGenesPerChromosome))
-		for i := 0; i < numberOfGenesPerChromosome; i++ {
-			select {
-			case <-quit:
-				quit <- true
-				return
-			default:
-				gene := <-nextGene
-				if len(gene) == 0 {
-					return
-				}
-				c.WriteString(gene)
-			}
-		}
-		select {
-		case <-quit:
-			quit <- true
-			return
-		default:
-			nextChromosome <- c.String()
-		}
+func newGeneGenerator(geneSet string, numberOfGenesPerChromosome int, random RandomSource) *geneGenerator {
+	genesPerDraw, drawRange := 1, len(geneSet)
+	for genesPerDraw < 64 && drawRange <= maxDrawRange/len(geneSet) {
+		genesPerDraw++
+		drawRange *= len(geneSet)
+	}
+	return &geneGenerator{
+		geneSet:                    geneSet,
+		numberOfGenesPerChromosome: numberOfGenesPerChromosome,
+		random:                     random,
+		genesPerDraw:               genesPerDraw,
+		drawRange:                  drawRange,
+		batch:                      make([]int, 0, genesPerDraw),
 	}
 }
 
-func generateGene(nextGene chan string, geneSet string, quit chan bool) {
-	localRand := createRandomNumberGenerator()
-	defer func() { close(nextGene) }()
-	for {
-		index := localRand.Intn(len(geneSet))
-		select {
-		case <-quit:
-			quit <- true
-			return
-		default:
-			nextGene <- geneSet[index : index+1]
+func (generator *geneGenerator) nextIndex() int {
+	if len(generator.batch) == 0 {
+		draw := generator.random.Intn(generator.drawRange)
+		for i := 0; i < generator.genesPerDraw; i++ {
+			generator.batch = append(generator.batch, draw%len(generator.geneSet))
+			draw /= len(generator.geneSet)
 		}
 	}
+	index := generator.batch[len(generator.batch)-1]
+	generator.batch = generator.batch[:len(generator.batch)-1]
+	return index
+}
+
+func (generator *geneGenerator) gene() byte {
+	return generator.geneSet[generator.nextIndex()]
+}
+
+// appendChromosome appends one chromosome of new genes to genes.
+func (generator *geneGenerator) appendChromosome(genes []byte) []byte {
+	for i := 0; i < generator.numberOfGenesPerChromosome; i++ {
+		genes = append(genes, generator.gene())
+	}
+	return genes
 }
 
-func generateParent(nextChromosome chan string, geneSet string, numberOfChromosomes, numberOfGenesPerChromosome int) string {
-	s := bytes.NewBuffer(make([]byte, 0, numberOfChromosomes*numberOfGenesPerChromosome))
+func (generator *geneGenerator) parent(numberOfChromosomes int) []byte {
+	genes := make([]byte, 0, numberOfChromosomes*generator.numberOfGenesPerChromosome)
 	for i := 0; i < numberOfChromosomes; i++ {
-		s.WriteString(<-nextChromosome)
+		genes = generator.appendChromosome(genes)
 	}
-	return s.String()
+	return genes
 }
@@ -0,0 +1,59 @@
+package genetic
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+type countingRandom struct {
+	source *rand.Rand
+	calls  int
+}
+
+func (random *countingRandom) Intn(exclusiveMax int) int {
+	random.calls++
+	return random.source.Intn(exclusiveMax)
+}
+
+func TestGeneGeneratorDrawsEveryGeneEvenly(t *testing.T) {
+	for _, geneSet := range []string{"01", "abcdefghijklmnopqrstuvwxyz", strings.Repeat("x", 299) + "y"} {
+		random := &countingRandom{source: rand.New(rand.NewSource(1))}
+		genes := newGeneGenerator(geneSet, 3, random)
+
+		const count = 60000
+		parent := genes.parent(count / 3)
+		if len(parent) != count {
+			t.Fatalf("expected %d genes, got %d", count, len(parent))
+		}
+		counts := make(map[byte]int)
+		for i := 0; i < len(parent); i++ {
+			counts[parent[i]]++
+		}
+		for i := 0; i < len(geneSet); i++ {
+			expected := strings.Count(geneSet, geneSet[i:i+1]) * count / len(geneSet)
+			if actual := counts[geneSet[i]]; actual < expected*8/10 || actual > expected*12/10 {
+				t.Errorf("%.10s: expected about %d of %c, got %d", geneSet, expected, geneSet[i], actual)
+			}
+		}
+		if random.calls > count/genes.genesPerDraw+1 {
+			t.Errorf("%.10s: expected %d genes per draw, made %d draws for %d genes",
+				geneSet, genes.genesPerDraw, random.calls, count)
+		}
+	}
+}
+
+func BenchmarkGeneGenerator(b *testing.B) {
+	genes := newGeneGenerator("abcdefghijklmnopqrstuvwxyz", 8, createSeededRandomNumberGenerators(1)())
+	b.Run("gene", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			genes.gene()
+		}
+	})
+	b.Run("chromosome", func(b *testing.B) {
+		chromosome := make([]byte, 0, 8)
+		for i := 0; i < b.N; i++ {
+			genes.appendChromosome(chromosome[:0])
+		}
+	})
+}
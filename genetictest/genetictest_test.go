@@ -0,0 +1,97 @@
+package genetictest
+
+import (
+	genetic "github.com/handcraftsman/GeneticGo"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestScriptReplaysValues(t *testing.T) {
+	script := NewScript(t, 2, 0, 4)
+	for _, expected := range []int{2, 0, 4} {
+		if value := script.Intn(5); value != expected {
+			t.Errorf("expected %d, got %d", expected, value)
+		}
+	}
+	if script.Remaining() != 0 {
+		t.Errorf("expected no values left, got %d", script.Remaining())
+	}
+	if calls := script.Calls(); !reflect.DeepEqual(calls, []int{5, 5, 5}) {
+		t.Errorf("expected three calls of Intn(5), got %v", calls)
+	}
+}
+
+func TestScriptFailsOutOfRangeValues(t *testing.T) {
+	recorder := &failureRecorder{TB: t}
+	done := make(chan bool)
+	go func() {
+		defer close(done)
+		NewScript(recorder, 3).Intn(3)
+	}()
+	<-done
+	if !strings.Contains(recorder.message, "out of range") {
+		t.Errorf("expected an out of range failure, got %q", recorder.message)
+	}
+}
+
+type failureRecorder struct {
+	testing.TB
+	message string
+}
+
+func (recorder *failureRecorder) Helper() {}
+
+func (recorder *failureRecorder) Fatalf(format string, args ...interface{}) {
+	recorder.message = format
+	runtime.Goexit()
+}
+
+func TestOperatorHarnessRepeatsWithTheSameRandom(t *testing.T) {
+	run := func() []Improvement {
+		harness := OperatorHarness{
+			GetFitness:              func(genes string) int { return strings.Count(genes, "a") },
+			LowerFitnessesAreBetter: true,
+			Operators:               genetic.Operators("abcd", 2),
+			Random:                  rand.New(rand.NewSource(7)),
+		}
+		return harness.Run([]string{"aaaaaaaa", "aabbaabb"}, 300)
+	}
+
+	first, second := run(), run()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same run twice, got\n%v\n%v", first, second)
+	}
+	if first[0].Genes != "aabbaabb" || first[0].Step != 0 {
+		t.Errorf("expected to start from the best initial genome, got %+v", first[0])
+	}
+	for i := 1; i < len(first); i++ {
+		if first[i].Fitness >= first[i-1].Fitness {
+			t.Errorf("expected each improvement to be better, got %+v", first)
+		}
+	}
+}
+
+func TestOperatorHarnessChecksEveryChild(t *testing.T) {
+	children := 0
+	harness := OperatorHarness{
+		GetFitness: func(genes string) int { return len(genes) },
+		Operators:  genetic.Operators("ab", 1)[:1], // add
+		Random:     NewScript(t, 0, 0, 1, 0, 0, 1),
+		Check: func(operator string, parents []string, child string) {
+			children++
+			AssertWholeChromosomes(t, child, 1, parents...)
+		},
+	}
+
+	improvements := harness.Run([]string{"a", "b"}, 2)
+
+	if children != 2 {
+		t.Errorf("expected 2 children, got %d", children)
+	}
+	if best := improvements[len(improvements)-1]; best.Genes != "aba" {
+		t.Errorf("expected aba, got %+v", best)
+	}
+}
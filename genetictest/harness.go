@@ -0,0 +1,106 @@
+package genetictest
+
+import (
+	genetic "github.com/handcraftsman/GeneticGo"
+	s "sort"
+)
+
+// OperatorHarness exercises operators on the calling goroutine. It is not
+// the solver's evolver: it keeps its own pool of the best distinct genomes
+// and draws operators and parents uniformly with Random, leaving out the
+// solver's pool, selection and strategy weighting. Use it to check what
+// operators make and that a fitness function rewards them; the same Random
+// gives the same run every time.
+type OperatorHarness struct {
+	GetFitness              func(genes string) int
+	LowerFitnessesAreBetter bool
+	Operators               []genetic.Operator
+	Random                  genetic.RandomSource
+	PoolSize                int // defaults to 10
+
+	// Check, when set, is called with every child an operator makes and
+	// the parents it drew.
+	Check func(operator string, parents []string, child string)
+}
+
+// Improvement is a new best genome found by an OperatorHarness.
+type Improvement struct {
+	Step     int
+	Operator string
+	Genes    string
+	Fitness  int
+}
+
+type candidate struct {
+	genes   string
+	fitness int
+}
+
+// Run evolves from initial for the given number of steps, one child per
+// step, and returns each new best in the order found, starting with the
+// best of initial at step 0.
+func (harness *OperatorHarness) Run(initial []string, steps int) []Improvement {
+	poolSize := harness.PoolSize
+	if poolSize < 1 {
+		poolSize = 10
+	}
+	isBetter := func(a, b int) bool {
+		if harness.LowerFitnessesAreBetter {
+			return a < b
+		}
+		return a > b
+	}
+
+	var pool []candidate
+	distinct := make(map[string]bool)
+	add := func(genes string) {
+		if distinct[genes] {
+			return
+		}
+		distinct[genes] = true
+		child := candidate{genes, harness.GetFitness(genes)}
+		if len(pool) == poolSize {
+			if !isBetter(child.fitness, pool[len(pool)-1].fitness) {
+				return
+			}
+			pool = pool[:len(pool)-1]
+		}
+		index := s.Search(len(pool), func(i int) bool { return isBetter(child.fitness, pool[i].fitness) })
+		pool = append(pool, candidate{})
+		copy(pool[index+1:], pool[index:])
+		pool[index] = child
+	}
+
+	for _, genes := range initial {
+		add(genes)
+	}
+	if len(pool) == 0 {
+		return nil
+	}
+	improvements := []Improvement{{Operator: "initial", Genes: pool[0].genes, Fitness: pool[0].fitness}}
+
+	for step := 1; step <= steps; step++ {
+		operator := harness.Operators[harness.Random.Intn(len(harness.Operators))]
+		var parents []string
+		parent := func() string {
+			genes := pool[harness.Random.Intn(len(pool))].genes
+			parents = append(parents, genes)
+			return genes
+		}
+
+		child := operator.Apply(parent, harness.Random)
+		if child == "" {
+			continue
+		}
+		if harness.Check != nil {
+			harness.Check(operator.Name, parents, child)
+		}
+
+		best := pool[0]
+		add(child)
+		if isBetter(pool[0].fitness, best.fitness) {
+			improvements = append(improvements, Improvement{step, operator.Name, pool[0].genes, pool[0].fitness})
+		}
+	}
+	return improvements
+}
@@ -0,0 +1,78 @@
+package genetictest
+
+import (
+	genetic "github.com/handcraftsman/GeneticGo"
+	"testing"
+)
+
+// Apply runs operator once, handing it parents in order, round robin, and
+// returns the child with the parents it drew.
+func Apply(operator genetic.Operator, random genetic.RandomSource, parents ...string) (string, []string) {
+	var drawn []string
+	parent := func() string {
+		genes := parents[len(drawn)%len(parents)]
+		drawn = append(drawn, genes)
+		return genes
+	}
+	return operator.Apply(parent, random), drawn
+}
+
+// AssertAligned fails t unless genes is a whole, non-zero number of
+// chromosomes.
+func AssertAligned(t testing.TB, genes string, numberOfGenesPerChromosome int) {
+	t.Helper()
+	if len(genes) == 0 || len(genes)%numberOfGenesPerChromosome != 0 {
+		t.Errorf("%q is not a whole number of %d gene chromosomes", genes, numberOfGenesPerChromosome)
+	}
+}
+
+// AssertChromosomes fails t unless genes is exactly want chromosomes long.
+func AssertChromosomes(t testing.TB, genes string, numberOfGenesPerChromosome, want int) {
+	t.Helper()
+	if len(genes) != want*numberOfGenesPerChromosome {
+		t.Errorf("%q has %d genes, expected %d chromosomes of %d",
+			genes, len(genes), want, numberOfGenesPerChromosome)
+	}
+}
+
+// AssertWholeChromosomes fails t unless each chromosome of child is also a
+// chromosome of one of the parents, that is the operator moved whole
+// chromosomes without splitting any.
+func AssertWholeChromosomes(t testing.TB, child string, numberOfGenesPerChromosome int, parents ...string) {
+	t.Helper()
+	n := numberOfGenesPerChromosome
+	found := make(map[string]bool)
+	for _, parent := range parents {
+		for i := 0; i+n <= len(parent); i += n {
+			found[parent[i:i+n]] = true
+		}
+	}
+	for i := 0; i+n <= len(child); i += n {
+		if !found[child[i:i+n]] {
+			t.Errorf("chromosome %q at %d of %q is not a chromosome of %q", child[i:i+n], i, child, parents)
+			return
+		}
+	}
+}
+
+// AssertWithinOneChromosome fails t unless child is as long as parent and
+// differs from it only inside a single chromosome.
+func AssertWithinOneChromosome(t testing.TB, parent, child string, numberOfGenesPerChromosome int) {
+	t.Helper()
+	if len(child) != len(parent) {
+		t.Errorf("%q has %d genes, expected %d like its parent %q", child, len(child), len(parent), parent)
+		return
+	}
+	changed := -1
+	for i := range child {
+		if child[i] == parent[i] {
+			continue
+		}
+		chromosome := i / numberOfGenesPerChromosome
+		if changed >= 0 && changed != chromosome {
+			t.Errorf("%q differs from its parent %q in chromosomes %d and %d", child, parent, changed, chromosome)
+			return
+		}
+		changed = chromosome
+	}
+}
@@ -0,0 +1,52 @@
+// Package genetictest helps test fitness functions and operators without
+// the solver's goroutines and clock: a scripted random source, an operator
+// harness that evolves on the calling goroutine, and assertions for the
+// length and chromosome alignment of the genomes operators make.
+//
+//	random := genetictest.NewScript(t, 1, 0, 2) // the values Intn returns
+//	child, parents := genetictest.Apply(operator, random, "abcdef")
+//	genetictest.AssertAligned(t, child, 3)
+package genetictest
+
+import (
+	"testing"
+)
+
+// Script is a genetic.RandomSource that returns scripted values in order.
+// It fails the test if a value is out of range for the Intn call that
+// receives it or if the script runs out.
+type Script struct {
+	t      testing.TB
+	values []int
+	calls  []int
+}
+
+// NewScript creates a Script that returns values in order.
+func NewScript(t testing.TB, values ...int) *Script {
+	return &Script{t: t, values: values}
+}
+
+// Intn returns the next scripted value.
+func (script *Script) Intn(exclusiveMax int) int {
+	script.t.Helper()
+	call := len(script.calls)
+	script.calls = append(script.calls, exclusiveMax)
+	if call >= len(script.values) {
+		script.t.Fatalf("script ran out at call %d, Intn(%d)", call+1, exclusiveMax)
+	}
+	value := script.values[call]
+	if value < 0 || value >= exclusiveMax {
+		script.t.Fatalf("scripted value %d at call %d is out of range for Intn(%d)", value, call+1, exclusiveMax)
+	}
+	return value
+}
+
+// Calls returns the exclusiveMax of each Intn call so far.
+func (script *Script) Calls() []int {
+	return append([]int(nil), script.calls...)
+}
+
+// Remaining returns how many scripted values have not been used.
+func (script *Script) Remaining() int {
+	return max(0, len(script.values)-len(script.calls))
+}
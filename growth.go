@@ -0,0 +1,104 @@
+package genetic
+
+import (
+	"strings"
+)
+
+// GrowthPolicy decides how hill climbing lengthens a sequence by a
+// chromosome. Policies can be combined, e.g.
+// InsertChromosome|DuplicateChromosome, in which case each child grows by
+// one of them chosen at random.
+type GrowthPolicy int
+
+const (
+	// AppendChromosome adds a new chromosome at the end. It is the
+	// default.
+	AppendChromosome GrowthPolicy = 1 << iota
+	// InsertChromosome adds a new chromosome at a random position.
+	InsertChromosome
+	// DuplicateChromosome inserts a copy of one of the sequence's own
+	// chromosomes at a random position.
+	DuplicateChromosome
+	// TransplantChromosome inserts a copy of a chromosome of another
+	// pool member at a random position.
+	TransplantChromosome
+)
+
+var growthPolicyNames = []string{"append", "insert", "duplicate", "transplant"}
+
+// growthStrategyNames credit the strategies that grew a child, in the
+// same order. Appending keeps the name of the strategy that did it.
+var growthStrategyNames = []string{"", "insert    ", "duplicate ", "transplant"}
+
+func (policy GrowthPolicy) String() string {
+	var names []string
+	for i, name := range growthPolicyNames {
+		if policy&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return growthPolicyNames[0]
+	}
+	return strings.Join(names, ",")
+}
+
+func (policy GrowthPolicy) MarshalText() ([]byte, error) {
+	return []byte(policy.String()), nil
+}
+
+// UnmarshalText accepts policy names separated by commas, e.g.
+// "insert,duplicate".
+func (policy *GrowthPolicy) UnmarshalText(text []byte) error {
+	*policy = 0
+	for _, name := range strings.Split(string(text), ",") {
+		value, err := parseEnumName(growthPolicyNames, "GrowthPolicy", strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		*policy |= 1 << value
+	}
+	return nil
+}
+
+// choose returns one of the combined policies, or AppendChromosome if
+// there are none.
+func (policy GrowthPolicy) choose(random RandomSource) GrowthPolicy {
+	var policies []GrowthPolicy
+	for i := range growthPolicyNames {
+		if policy&(1<<i) != 0 {
+			policies = append(policies, 1<<i)
+		}
+	}
+	switch len(policies) {
+	case 0:
+		return AppendChromosome
+	case 1:
+		return policies[0]
+	}
+	return policies[random.Intn(len(policies))]
+}
+
+// strategy returns grower renamed for the policy, so improvements are
+// credited to the way the child grew.
+func (policy GrowthPolicy) strategy(grower strategyInfo) strategyInfo {
+	for i, name := range growthStrategyNames {
+		if policy == 1<<i && name != "" {
+			grower.name = name
+		}
+	}
+	return grower
+}
+
+// growGenes lengthens parentGenes by a chromosome according to policy,
+// which must not be AppendChromosome, drawing a transplanted chromosome
+// from donorGenes.
+func growGenes(child, parentGenes, donorGenes []byte, policy GrowthPolicy, nextGene func() byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	switch policy {
+	case InsertChromosome:
+		return insertGenes(child, parentGenes, nextGene, numberOfGenesPerChromosome, random)
+	case DuplicateChromosome:
+		return duplicateGenes(child, parentGenes, numberOfGenesPerChromosome, random)
+	}
+	return transplantGenes(child, parentGenes, donorGenes, numberOfGenesPerChromosome, random)
+}
@@ -0,0 +1,117 @@
+// Package solverconfig sets genetic.Solver fields by name so the commands
+// can configure a solver from flags and config files.
+package solverconfig
+
+import (
+	"encoding"
+	"fmt"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"math"
+	"reflect"
+	s "sort"
+	"strconv"
+	"strings"
+)
+
+// Apply sets each named field to its value, see Set.
+func Apply(solver *genetic.Solver, values map[string]interface{}) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	s.Strings(names)
+	for _, name := range names {
+		if err := Set(solver, name, values[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Set assigns value to the exported Solver field with the given name,
+// ignoring case. The value may be a string, as from a command line flag,
+// or a number or bool as decoded from JSON or TOML. Enumerations such as
+// Selection accept their names, e.g. "tournament".
+func Set(solver *genetic.Solver, name string, value interface{}) error {
+	field := reflect.ValueOf(solver).Elem().FieldByNameFunc(func(fieldName string) bool {
+		return strings.EqualFold(fieldName, name)
+	})
+	if !field.IsValid() || !field.CanSet() {
+		return fmt.Errorf("unknown solver setting %q, expected one of %v", name, Names())
+	}
+
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("solver setting %s needs a name, got %v", name, value)
+		}
+		return unmarshaler.UnmarshalText([]byte(text))
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			field.SetBool(v)
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("solver setting %s: %v", name, err)
+			}
+			field.SetBool(parsed)
+		default:
+			return fmt.Errorf("solver setting %s needs true or false, got %v", name, value)
+		}
+	case reflect.Int, reflect.Int64:
+		number, err := toFloat(value)
+		if err != nil || number != math.Trunc(number) {
+			return fmt.Errorf("solver setting %s needs a whole number, got %v", name, value)
+		}
+		field.SetInt(int64(number))
+	case reflect.Float64:
+		number, err := toFloat(value)
+		if err != nil {
+			return fmt.Errorf("solver setting %s needs a number, got %v", name, value)
+		}
+		field.SetFloat(number)
+	default:
+		return fmt.Errorf("solver setting %s cannot be configured by value", name)
+	}
+	return nil
+}
+
+// Names returns the Solver fields that Set can assign.
+func Names() []string {
+	var names []string
+	solverType := reflect.TypeOf((*genetic.Solver)(nil)).Elem()
+	textUnmarshaler := reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	for i := 0; i < solverType.NumField(); i++ {
+		field := solverType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+			names = append(names, field.Name)
+		default:
+			if reflect.PointerTo(field.Type).Implements(textUnmarshaler) {
+				names = append(names, field.Name)
+			}
+		}
+	}
+	return names
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("%v is not a number", value)
+}
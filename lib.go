@@ -1,19 +1,56 @@
 package genetic
 
 import (
+	"fmt"
 	rnd "github.com/handcraftsman/Random"
 	"math/rand"
 	"runtime"
 	s "sort"
+	"sync"
 	"time"
 )
 
-func createRandomNumberGenerator() randomSource {
+func createRandomNumberGenerator() RandomSource {
 	procs := runtime.GOMAXPROCS(-1)
 	if procs > 1 {
 		return rnd.NewRandom()
 	}
-	return rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &lockedRandom{source: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+type lockedRandom struct {
+	lock   sync.Mutex
+	source *rand.Rand
+}
+
+func (random *lockedRandom) Intn(exclusiveMax int) int {
+	random.lock.Lock()
+	defer random.lock.Unlock()
+	return random.source.Intn(exclusiveMax)
+}
+
+// createSeededRandomNumberGenerators returns a function that creates
+// random sources seeded in sequence, starting from seed.
+func createSeededRandomNumberGenerators(seed int64) func() RandomSource {
+	next := seed
+	var lock sync.Mutex
+	return func() RandomSource {
+		lock.Lock()
+		defer lock.Unlock()
+		next++
+		return &lockedRandom{source: rand.New(rand.NewSource(next))}
+	}
+}
+
+// resetTimer restarts timer, which may have gone off without being read.
+func resetTimer(timer *time.Timer, duration time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(duration)
 }
 
 func insertionSort(items []*sequenceInfo, compare func(*sequenceInfo, *sequenceInfo) bool, index int) {
@@ -45,15 +82,36 @@ func min(a, b int) int {
 	return b
 }
 
-func reverseArray(a []string) {
-	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
-		a[i], a[j] = a[j], a[i]
-	}
-}
-
 func sort(a, b int) (int, int) {
 	if a < b {
 		return a, b
 	}
 	return b, a
 }
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+func enumName(names []string, value int) string {
+	if value < 0 || value >= len(names) {
+		return fmt.Sprint(value)
+	}
+	return names[value]
+}
+
+func parseEnumName(names []string, typeName, text string) (int, error) {
+	for value, name := range names {
+		if name == text {
+			return value, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s %q, expected one of %v", typeName, text, names)
+}
@@ -0,0 +1,43 @@
+package genetic
+
+import (
+	"sync"
+)
+
+// lifetime ends a group of goroutines together: stop closes done, which
+// every goroutine in the group watches, then waits for them to return.
+type lifetime struct {
+	done    chan struct{}
+	once    sync.Once
+	running sync.WaitGroup
+}
+
+func newLifetime() *lifetime {
+	return &lifetime{done: make(chan struct{})}
+}
+
+// start runs f in a goroutine that stop waits for. f must return once
+// done is closed.
+func (l *lifetime) start(f func()) {
+	l.running.Add(1)
+	go func() {
+		defer l.running.Done()
+		f()
+	}()
+}
+
+// send delivers item on channel without blocking the caller, giving up
+// if the lifetime ends first.
+func (l *lifetime) send(channel chan *sequenceInfo, item *sequenceInfo) {
+	l.start(func() {
+		select {
+		case channel <- item:
+		case <-l.done:
+		}
+	})
+}
+
+func (l *lifetime) stop() {
+	l.once.Do(func() { close(l.done) })
+	l.running.Wait()
+}
@@ -0,0 +1,90 @@
+package genetic
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func countOnes(candidate string) int {
+	return strings.Count(candidate, "1")
+}
+
+func TestGetBestLeavesNoGoroutinesBehind(t *testing.T) {
+	runs := map[string]func(solver *Solver){
+		"fixed": func(solver *Solver) {
+			solver.GetBest(countOnes, func(string) {}, "01", 50, 1)
+		},
+		"concurrent evolvers": func(solver *Solver) {
+			solver.NumberOfConcurrentEvolvers = 3
+			solver.GetBest(countOnes, func(string) {}, "01", 50, 1)
+		},
+		"hill climbing": func(solver *Solver) {
+			solver.MaxRoundsWithoutImprovement = 2
+			solver.GetBestUsingHillClimbing(countOnes, func(string) {}, "01", 20, 2, 40)
+		},
+		"novelty": func(solver *Solver) {
+			solver.Behavior = func(genes string) []float64 { return []float64{float64(countOnes(genes))} }
+			solver.GetBest(countOnes, func(string) {}, "01", 50, 1)
+		},
+	}
+
+	baseline := runtime.NumGoroutine()
+	for name, run := range runs {
+		for i := 0; i < 3; i++ {
+			solver := newTestSolver()
+			solver.RandomSeed = int64(i + 1)
+			run(solver)
+		}
+
+		// goroutines that have finished may take a moment to be counted out
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if count := runtime.NumGoroutine(); count > baseline {
+			buffer := make([]byte, 1<<20)
+			t.Fatalf("%s: expected %d goroutines after the runs, got %d\n%s",
+				name, baseline, count, buffer[:runtime.Stack(buffer, true)])
+		}
+	}
+}
+
+func TestResultIsTheLastGenesDisplayed(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		solver := new(Solver)
+		solver.MaxSecondsToRunWithoutImprovement = .05
+		solver.NumberOfConcurrentEvolvers = 3
+		solver.PoolSize = 20
+
+		var lock sync.Mutex
+		var displayed []string
+		display := func(genes string) {
+			lock.Lock()
+			defer lock.Unlock()
+			displayed = append(displayed, genes)
+		}
+		slowFitness := func(candidate string) int {
+			time.Sleep(20 * time.Microsecond)
+			return countOnes(candidate)
+		}
+
+		best := solver.GetBest(slowFitness, display, "01", 30, 1)
+
+		lock.Lock()
+		count := len(displayed)
+		if count > 0 && displayed[count-1] != best {
+			t.Errorf("expected the result %s to be the last genes displayed, %s", best, displayed[count-1])
+		}
+		lock.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		lock.Lock()
+		if len(displayed) != count {
+			t.Errorf("expected nothing to be displayed after GetBest returned, got %v", displayed[count:])
+		}
+		lock.Unlock()
+	}
+}
@@ -0,0 +1,26 @@
+package genetic
+
+import (
+	"context"
+	"log/slog"
+)
+
+// logger returns the Logger the solver reports through. When none is
+// configured the library stays silent unless PrintDiagnosticInfo or
+// PrintStrategyUsage asks for output, which then goes to slog.Default().
+func (solver *Solver) logger() *slog.Logger {
+	if solver.Logger != nil {
+		return solver.Logger
+	}
+	if solver.PrintDiagnosticInfo || solver.PrintStrategyUsage {
+		return slog.Default()
+	}
+	return slog.New(discardHandler{})
+}
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
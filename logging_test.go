@@ -0,0 +1,41 @@
+package genetic
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSolverReportsThroughLogger(t *testing.T) {
+	var output bytes.Buffer
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = .1
+	solver.PrintDiagnosticInfo = true
+	solver.PrintStrategyUsage = true
+	solver.Logger = slog.New(slog.NewTextHandler(&output, nil))
+
+	target := "hello"
+	solver.GetBest(func(candidate string) int {
+		return len(target) - HammingDistance(target, candidate)
+	}, func(string) {}, "ehlo", len(target), 1)
+
+	for _, expected := range []string{
+		"msg=improvement evolver=1 strategy=",
+		"msg=\"evolver finished\" evolver=1",
+		"lengths=map[5:",
+		"msg=\"run summary\"",
+	} {
+		if !strings.Contains(output.String(), expected) {
+			t.Errorf("expected log to contain %q, got:\n%s", expected, output.String())
+		}
+	}
+}
+
+func TestSolverIsSilentByDefault(t *testing.T) {
+	solver := new(Solver)
+	if solver.logger().Enabled(context.Background(), slog.LevelError) {
+		t.Error("expected the default logger to discard everything")
+	}
+}
@@ -0,0 +1,66 @@
+package genetic
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	s "sort"
+	"strings"
+)
+
+// MetricsHandler serves the statistics of the solver's current, or most
+// recent, run in the Prometheus text exposition format.
+func (solver *Solver) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		writeMetrics(w, solver.Statistics())
+	})
+}
+
+func writeMetrics(w io.Writer, statistics Statistics) {
+	writeMetric(w, "geneticgo_elapsed_seconds", "gauge", "Seconds since the run started.", statistics.Elapsed.Seconds())
+	writeMetric(w, "geneticgo_evaluations_total", "counter", "Fitness evaluations.", statistics.Evaluations)
+	writeMetric(w, "geneticgo_evaluations_per_second", "gauge", "Mean fitness evaluations per second.", statistics.EvaluationsPerSecond())
+	writeMetric(w, "geneticgo_fitness_cache_hits_total", "counter", "Candidates not evaluated because they were already in the pool.", statistics.CacheHits)
+	writeMetric(w, "geneticgo_fitness_cache_hit_ratio", "gauge", "Fraction of candidates not evaluated because they were already in the pool.", statistics.CacheHitRate())
+	writeMetric(w, "geneticgo_evolver_restarts_total", "counter", "Evolvers restarted from the best sequence found.", statistics.EvolverRestarts)
+	writeMetric(w, "geneticgo_stagnation_responses_total", "counter", "Pools reseeded, hypermutated or restarted because they stagnated.", statistics.StagnationResponses)
+
+	writeHeader(w, "geneticgo_improvements_total", "counter", "Improvements on the best sequence by strategy.")
+	strategies := make([]string, 0, len(statistics.Improvements))
+	for strategy := range statistics.Improvements {
+		strategies = append(strategies, strategy)
+	}
+	s.Strings(strategies)
+	for _, strategy := range strategies {
+		fmt.Fprintf(w, "geneticgo_improvements_total{strategy=\"%s\"} %v\n", escapeLabel(strategy), statistics.Improvements[strategy])
+	}
+
+	writeHeader(w, "geneticgo_best_fitness", "gauge", "Fitness of the best sequence found by each evolver.")
+	for _, evolver := range statistics.Evolvers {
+		fmt.Fprintf(w, "geneticgo_best_fitness{evolver=\"%d\"} %v\n", evolver.Id, evolver.BestFitness)
+	}
+	writeHeader(w, "geneticgo_pool_size", "gauge", "Sequences in each evolver's pool.")
+	for _, evolver := range statistics.Evolvers {
+		fmt.Fprintf(w, "geneticgo_pool_size{evolver=\"%d\"} %v\n", evolver.Id, evolver.PoolSize)
+	}
+	writeHeader(w, "geneticgo_pool_diversity", "gauge", "Mean distance between members of each evolver's pool when it last finished.")
+	for _, evolver := range statistics.Evolvers {
+		fmt.Fprintf(w, "geneticgo_pool_diversity{evolver=\"%d\"} %v\n", evolver.Id, evolver.Diversity)
+	}
+}
+
+func writeHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func writeMetric(w io.Writer, name, metricType, help string, value interface{}) {
+	writeHeader(w, name, metricType, help)
+	fmt.Fprintf(w, "%s %v\n", name, value)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
@@ -0,0 +1,67 @@
+package genetic
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandlerServesPrometheusTextFormat(t *testing.T) {
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = .1
+	solver.NumberOfConcurrentEvolvers = 2
+
+	server := httptest.NewServer(solver.MetricsHandler())
+	defer server.Close()
+
+	target := "hello world"
+	solver.GetBest(func(candidate string) int {
+		return len(target) - HammingDistance(target, candidate)
+	}, func(string) {}, " dehlorw", len(target), 1)
+
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if !strings.HasPrefix(response.Header.Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("unexpected content type %q", response.Header.Get("Content-Type"))
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"# TYPE geneticgo_evaluations_total counter\n",
+		"# TYPE geneticgo_evaluations_per_second gauge\n",
+		"geneticgo_fitness_cache_hit_ratio ",
+		"geneticgo_evolver_restarts_total ",
+		"geneticgo_improvements_total{strategy=\"",
+		"geneticgo_best_fitness{evolver=\"1\"} ",
+		"geneticgo_best_fitness{evolver=\"2\"} ",
+		"geneticgo_pool_size{evolver=\"1\"} ",
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected scrape to contain %q, got:\n%s", expected, body)
+		}
+	}
+	if strings.Contains(string(body), "geneticgo_evaluations_total 0\n") {
+		t.Error("expected evaluations to be counted")
+	}
+}
+
+func TestMetricsHandlerBeforeFirstRun(t *testing.T) {
+	solver := new(Solver)
+	recorder := httptest.NewRecorder()
+	solver.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", recorder.Code)
+	}
+	if !strings.Contains(recorder.Body.String(), "geneticgo_evaluations_total 0\n") {
+		t.Errorf("expected zero evaluations, got:\n%s", recorder.Body.String())
+	}
+}
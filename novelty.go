@@ -0,0 +1,134 @@
+package genetic
+
+import (
+	"math"
+	"sync"
+)
+
+type noveltySearch struct {
+	behavior      func(genes string) []float64
+	neighbors     int
+	threshold     float64
+	archiveSize   int
+	fitnessWeight float64
+	lowerIsBetter bool
+
+	lock            sync.Mutex
+	archive         [][]float64
+	evaluationCount int
+	best            *sequenceInfo
+}
+
+func newNoveltySearch(solver *Solver) *noveltySearch {
+	if solver.Behavior == nil {
+		return nil
+	}
+	novelty := noveltySearch{
+		behavior:      solver.Behavior,
+		neighbors:     solver.NoveltyNeighbors,
+		threshold:     solver.NoveltyArchiveThreshold,
+		archiveSize:   solver.NoveltyArchiveSize,
+		fitnessWeight: solver.NoveltyFitnessWeight,
+		lowerIsBetter: solver.LowerFitnessesAreBetter,
+	}
+	if novelty.neighbors < 1 {
+		novelty.neighbors = 15
+	}
+	if novelty.archiveSize < 1 {
+		novelty.archiveSize = 1000
+	}
+	return &novelty
+}
+
+// score sets the sequence's behavior and ranks it by its mean distance to
+// the k nearest behaviors in the archive and the pool, plus its weighted
+// fitness.
+func (novelty *noveltySearch) score(sequence *sequenceInfo, p *pool) {
+	sequence.behavior = novelty.behavior(string(sequence.genes))
+
+	nearest := make([]float64, 0, novelty.neighbors)
+	consider := func(behavior []float64) {
+		if behavior == nil {
+			return
+		}
+		distance := behaviorDistance(sequence.behavior, behavior)
+		if len(nearest) < novelty.neighbors {
+			nearest = append(nearest, distance)
+		} else if distance < nearest[len(nearest)-1] {
+			nearest[len(nearest)-1] = distance
+		} else {
+			return
+		}
+		for i := len(nearest) - 1; i > 0 && nearest[i] < nearest[i-1]; i-- {
+			nearest[i], nearest[i-1] = nearest[i-1], nearest[i]
+		}
+	}
+
+	if p != nil {
+		for _, item := range p.snapshot() {
+			if item != sequence {
+				consider(item.behavior)
+			}
+		}
+	}
+
+	novelty.lock.Lock()
+	defer novelty.lock.Unlock()
+
+	for _, behavior := range novelty.archive {
+		consider(behavior)
+	}
+
+	sparseness := 0.0
+	for _, distance := range nearest {
+		sparseness += distance
+	}
+	if len(nearest) > 0 {
+		sparseness /= float64(len(nearest))
+	}
+
+	fitness := float64(sequence.fitness)
+	if novelty.lowerIsBetter {
+		fitness = -fitness
+	}
+	sequence.score = sparseness + novelty.fitnessWeight*fitness
+
+	novelty.evaluationCount++
+	if novelty.threshold > 0 && sparseness >= novelty.threshold ||
+		novelty.threshold <= 0 && novelty.evaluationCount%100 == 0 {
+		if len(novelty.archive) == novelty.archiveSize {
+			novelty.archive = novelty.archive[1:]
+		}
+		novelty.archive = append(novelty.archive, sequence.behavior)
+	}
+}
+
+func (novelty *noveltySearch) isNewBest(sequence *sequenceInfo, childFitnessIsBetter func(child, other *sequenceInfo) bool) bool {
+	novelty.lock.Lock()
+	defer novelty.lock.Unlock()
+
+	if novelty.best != nil && !childFitnessIsBetter(sequence, novelty.best) {
+		return false
+	}
+	novelty.best = sequence
+	return true
+}
+
+func behaviorDistance(a, b []float64) float64 {
+	total := 0.0
+	for i := 0; i < len(a) && i < len(b); i++ {
+		difference := a[i] - b[i]
+		total += difference * difference
+	}
+	return math.Sqrt(total)
+}
+
+func createNoveltyComparisonFunctions() (isBetter, isSameOrBetter func(child, other *sequenceInfo) bool) {
+	isBetter = func(child, other *sequenceInfo) bool {
+		return child.score > other.score
+	}
+	isSameOrBetter = func(child, other *sequenceInfo) bool {
+		return child.score >= other.score
+	}
+	return
+}
@@ -0,0 +1,116 @@
+package genetic
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// newTestNoveltySearch describes genes that are a number by that number.
+func newTestNoveltySearch(solver *Solver, archive ...float64) *noveltySearch {
+	solver.Behavior = func(genes string) []float64 {
+		value, _ := strconv.ParseFloat(genes, 64)
+		return []float64{value}
+	}
+	novelty := newNoveltySearch(solver)
+	for _, behavior := range archive {
+		novelty.archive = append(novelty.archive, []float64{behavior})
+	}
+	return novelty
+}
+
+func TestNoveltyIsTheMeanDistanceToTheNearestNeighbors(t *testing.T) {
+	novelty := newTestNoveltySearch(&Solver{NoveltyNeighbors: 2}, 0, 10, 3)
+	p := makePool(2, func(child, other *sequenceInfo) bool { return child.score >= other.score }, nil, createRandomNumberGenerator())
+	p.offer(&sequenceInfo{genes: []byte("4.5"), behavior: []float64{4.5}})
+
+	sequence := &sequenceInfo{genes: []byte("4")}
+	novelty.score(sequence, p)
+
+	// the nearest are 3 in the archive and 4.5 in the pool
+	if sequence.score != .75 {
+		t.Errorf("expected a score of .75, got %v", sequence.score)
+	}
+}
+
+func TestNoveltyBlendsInWeightedFitness(t *testing.T) {
+	for _, test := range []struct {
+		lowerFitnessesAreBetter bool
+		expected                float64
+	}{
+		{false, 3},
+		{true, -1},
+	} {
+		novelty := newTestNoveltySearch(&Solver{
+			NoveltyNeighbors:        1,
+			NoveltyFitnessWeight:    .5,
+			LowerFitnessesAreBetter: test.lowerFitnessesAreBetter,
+		}, 0)
+
+		sequence := &sequenceInfo{genes: []byte("1"), fitness: 4}
+		novelty.score(sequence, nil)
+
+		if sequence.score != test.expected {
+			t.Errorf("lower is better %v: expected a score of %v, got %v",
+				test.lowerFitnessesAreBetter, test.expected, sequence.score)
+		}
+	}
+}
+
+func TestNoveltyArchivesBehaviorsAtLeastAsNovelAsTheThreshold(t *testing.T) {
+	novelty := newTestNoveltySearch(&Solver{
+		NoveltyNeighbors:        1,
+		NoveltyArchiveThreshold: 2,
+		NoveltyArchiveSize:      2,
+	}, 0)
+
+	for _, genes := range []string{"1", "3", "6", "7"} {
+		novelty.score(&sequenceInfo{genes: []byte(genes)}, nil)
+	}
+
+	// 1 is too close to 0 and 7 to 6, and 0 made way for 6
+	if expected := [][]float64{{3}, {6}}; !reflect.DeepEqual(novelty.archive, expected) {
+		t.Errorf("expected archive %v, got %v", expected, novelty.archive)
+	}
+}
+
+func TestNoveltyArchivesEveryHundredthBehaviorWithoutAThreshold(t *testing.T) {
+	novelty := newTestNoveltySearch(&Solver{})
+
+	for i := 1; i <= 250; i++ {
+		novelty.score(&sequenceInfo{genes: []byte(strconv.Itoa(i))}, nil)
+	}
+
+	if expected := [][]float64{{100}, {200}}; !reflect.DeepEqual(novelty.archive, expected) {
+		t.Errorf("expected archive %v, got %v", expected, novelty.archive)
+	}
+}
+
+func TestNoveltySearchReturnsTheFittestRatherThanTheMostNovel(t *testing.T) {
+	solver := newTestSolver()
+	// the position of the first 1 says nothing about the number of ones
+	solver.Behavior = func(genes string) []float64 {
+		for i, gene := range genes {
+			if gene == '1' {
+				return []float64{float64(i)}
+			}
+		}
+		return []float64{float64(len(genes))}
+	}
+
+	var lock sync.Mutex
+	fittest := -1
+	getFitness := func(genes string) int {
+		fitness := countOnes(genes)
+		lock.Lock()
+		fittest = max(fittest, fitness)
+		lock.Unlock()
+		return fitness
+	}
+	best := solver.GetBest(getFitness, func(string) {}, "01", 20, 1)
+
+	if countOnes(best) != fittest {
+		t.Errorf("expected the fittest sequence, with %d ones, got %s", fittest, best)
+	}
+}
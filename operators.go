@@ -0,0 +1,323 @@
+package genetic
+
+import (
+	"strings"
+)
+
+// Operator is one of the solver's strategies as a plain function. Apply
+// draws the parents it needs from parent and takes every random choice,
+// new genes included, from random, so a scripted RandomSource replays it
+// exactly. It returns "" when the strategy does not apply to the parents
+// drawn, for example when removing the only chromosome.
+type Operator struct {
+	Name  string
+	Apply func(parent func() string, random RandomSource) string
+}
+
+// Operators returns the solver's strategies for genomes built from the
+// genes in geneSet, numberOfGenesPerChromosome to a chromosome.
+func Operators(geneSet string, numberOfGenesPerChromosome int) []Operator {
+	n := numberOfGenesPerChromosome
+	nextGene := func(random RandomSource) func() byte {
+		return func() byte {
+			return geneSet[random.Intn(len(geneSet))]
+		}
+	}
+	parentGenes := func(parent func() string) []byte {
+		return []byte(parent())
+	}
+
+	return []Operator{
+		{"add", func(parent func() string, random RandomSource) string {
+			parentA, parentB := parent(), parent()
+			if parentA == parentB {
+				return ""
+			}
+			return string(addGenes(nil, []byte(parentA), []byte(parentB), n))
+		}},
+		{"crossover", func(parent func() string, random RandomSource) string {
+			parentA, parentB := parentGenes(parent), parentGenes(parent)
+			return string(crossoverGenes(nil, parentA, parentB, n, random))
+		}},
+		{"duplicate", func(parent func() string, random RandomSource) string {
+			return string(duplicateGenes(nil, parentGenes(parent), n, random))
+		}},
+		{"flutter", func(parent func() string, random RandomSource) string {
+			return string(flutterGenes(nil, parentGenes(parent), geneSet, n, random))
+		}},
+		{"insert", func(parent func() string, random RandomSource) string {
+			return string(insertGenes(nil, parentGenes(parent), nextGene(random), n, random))
+		}},
+		{"mutate", func(parent func() string, random RandomSource) string {
+			return string(mutateGenes(nil, parentGenes(parent), nextGene(random), random))
+		}},
+		{"random", func(parent func() string, random RandomSource) string {
+			return string(randomGenes(nil, len(parent()), n, nextGene(random)))
+		}},
+		{"remove", func(parent func() string, random RandomSource) string {
+			return string(removeGenes(nil, parentGenes(parent), n, random))
+		}},
+		{"replace", func(parent func() string, random RandomSource) string {
+			return string(replaceGenes(nil, parentGenes(parent), nextGene(random), n, random))
+		}},
+		{"reverse", func(parent func() string, random RandomSource) string {
+			return string(reverseGenes(nil, parentGenes(parent), n, random))
+		}},
+		{"shift", func(parent func() string, random RandomSource) string {
+			return string(shiftGenes(nil, parentGenes(parent), n, random))
+		}},
+		{"swap", func(parent func() string, random RandomSource) string {
+			return string(swapGenes(nil, parentGenes(parent), n, random))
+		}},
+		{"transplant", func(parent func() string, random RandomSource) string {
+			parentA, parentB := parentGenes(parent), parentGenes(parent)
+			return string(transplantGenes(nil, parentA, parentB, n, random))
+		}},
+	}
+}
+
+// The functions below append the child's genes to child, which is
+// expected to be empty, and return the result. When a strategy does not
+// apply they return child still empty.
+
+// addGenes appends the last chromosome of parentB to parentA.
+func addGenes(child, parentA, parentB []byte, numberOfGenesPerChromosome int) []byte {
+	child = append(child, parentA...)
+	return append(child, parentB[len(parentB)-numberOfGenesPerChromosome:]...)
+}
+
+// crossoverGenes overwrites a run of parentA's chromosomes with a run of
+// parentB's.
+func crossoverGenes(child, parentAgenes, parentBgenes []byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	if len(parentAgenes) == numberOfGenesPerChromosome || len(parentBgenes) == numberOfGenesPerChromosome {
+		return child
+	}
+
+	sourceStart := random.Intn((len(parentBgenes)-1)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome
+	destinationStart := random.Intn((len(parentAgenes)-1)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome
+	maxLength := min(len(parentAgenes)-destinationStart, len(parentBgenes)-sourceStart) / numberOfGenesPerChromosome * numberOfGenesPerChromosome
+	length := (1 + random.Intn(maxLength/numberOfGenesPerChromosome-1)) * numberOfGenesPerChromosome
+
+	child = append(child, parentAgenes[:destinationStart]...)
+	child = append(child, parentBgenes[sourceStart:sourceStart+length]...)
+	return append(child, parentAgenes[destinationStart+length:]...)
+}
+
+// flutterGenes nudges some genes of one chromosome to their neighbors in
+// the gene set.
+func flutterGenes(child, parentGenes []byte, geneSet string, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	chromosomeIndex := chooseWeightedChromosome(len(parentGenes), numberOfGenesPerChromosome, random)
+
+	numberOfGenesToFlutter := 1 + random.Intn(numberOfGenesPerChromosome)
+	start := chromosomeIndex
+	if numberOfGenesToFlutter < numberOfGenesPerChromosome {
+		start += random.Intn(numberOfGenesPerChromosome - numberOfGenesToFlutter + 1)
+	}
+
+	child = append(child, parentGenes[:start]...)
+	anyChanged := false
+	for i := 0; i < numberOfGenesToFlutter; i++ {
+		modifier := random.Intn(5) - 2
+		if modifier == 0 {
+			if anyChanged {
+				child = append(child, parentGenes[start+i])
+				continue
+			}
+			modifier++
+			anyChanged = true
+		}
+		geneSetIndex := strings.IndexByte(geneSet, parentGenes[start+i])
+		geneSetIndex += modifier
+		if geneSetIndex < 0 {
+			geneSetIndex += len(geneSet)
+		} else if geneSetIndex >= len(geneSet) {
+			geneSetIndex -= len(geneSet)
+		}
+		child = append(child, geneSet[geneSetIndex])
+	}
+
+	return append(child, parentGenes[start+numberOfGenesToFlutter:]...)
+}
+
+// mutateGenes replaces one or two genes with different ones from
+// nextGene.
+func mutateGenes(child, parentGenes []byte, nextGene func() byte, random RandomSource) []byte {
+	child = append(child, parentGenes...)
+	mutateOneGene(child, nextGene, random)
+	if random.Intn(2) == 1 {
+		mutateOneGene(child, nextGene, random)
+	}
+	return child
+}
+
+func mutateOneGene(genes []byte, nextGene func() byte, random RandomSource) {
+	index := random.Intn(len(genes))
+
+	currentGene := genes[index]
+	gene := currentGene
+	for gene == currentGene {
+		gene = nextGene()
+	}
+	genes[index] = gene
+}
+
+// randomGenes strings whole chromosomes of genes from nextGene together
+// until there are at least length genes.
+func randomGenes(child []byte, length, numberOfGenesPerChromosome int, nextGene func() byte) []byte {
+	for len(child) < length {
+		for i := 0; i < numberOfGenesPerChromosome; i++ {
+			child = append(child, nextGene())
+		}
+	}
+	return child
+}
+
+// insertGenes adds a chromosome of genes from nextGene at a random
+// position.
+func insertGenes(child, parentGenes []byte, nextGene func() byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	position := random.Intn(len(parentGenes)/numberOfGenesPerChromosome+1) * numberOfGenesPerChromosome
+
+	child = append(child, parentGenes[:position]...)
+	for i := 0; i < numberOfGenesPerChromosome; i++ {
+		child = append(child, nextGene())
+	}
+	return append(child, parentGenes[position:]...)
+}
+
+// duplicateGenes inserts a copy of one chromosome at a random position.
+func duplicateGenes(child, parentGenes []byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	return transplantGenes(child, parentGenes, parentGenes, numberOfGenesPerChromosome, random)
+}
+
+// transplantGenes inserts a copy of one of parentB's chromosomes into
+// parentA at a random position.
+func transplantGenes(child, parentAgenes, parentBgenes []byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	source := random.Intn(len(parentBgenes)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome
+	position := random.Intn(len(parentAgenes)/numberOfGenesPerChromosome+1) * numberOfGenesPerChromosome
+
+	child = append(child, parentAgenes[:position]...)
+	child = append(child, parentBgenes[source:source+numberOfGenesPerChromosome]...)
+	return append(child, parentAgenes[position:]...)
+}
+
+// removeGenes drops one chromosome.
+func removeGenes(child, parentGenes []byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	if len(parentGenes) <= numberOfGenesPerChromosome {
+		return child
+	}
+
+	chromosomeIndex := random.Intn(len(parentGenes)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome
+
+	child = append(child, parentGenes[:chromosomeIndex]...)
+	return append(child, parentGenes[chromosomeIndex+numberOfGenesPerChromosome:]...)
+}
+
+// replaceGenes replaces a run of genes within one chromosome with genes
+// from nextGene.
+func replaceGenes(child, parentGenes []byte, nextGene func() byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	if len(parentGenes) == numberOfGenesPerChromosome {
+		return child
+	}
+
+	chromosomeIndex := random.Intn(len(parentGenes)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome
+
+	numberOfGenesToMutate := 1 + random.Intn(numberOfGenesPerChromosome)
+	start := 0
+	if numberOfGenesToMutate < numberOfGenesPerChromosome {
+		start = random.Intn(numberOfGenesPerChromosome - numberOfGenesToMutate + 1)
+	}
+
+	child = append(child, parentGenes[:chromosomeIndex+start]...)
+	for i := 0; i < numberOfGenesToMutate; i++ {
+		child = append(child, nextGene())
+	}
+	return append(child, parentGenes[chromosomeIndex+start+numberOfGenesToMutate:]...)
+}
+
+// reverseGenes reverses the order of a run of chromosomes.
+func reverseGenes(child, parentGenes []byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	if len(parentGenes) == numberOfGenesPerChromosome {
+		return child
+	}
+
+	reversePointA := random.Intn(len(parentGenes)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome
+	reversePointB := random.Intn(len(parentGenes)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome
+	for ; reversePointA == reversePointB; reversePointB = random.Intn(len(parentGenes)/numberOfGenesPerChromosome) * numberOfGenesPerChromosome {
+	}
+
+	min, max := sort(reversePointA, reversePointB)
+
+	child = append(child, parentGenes[:min]...)
+	for i := max - numberOfGenesPerChromosome; i >= min; i -= numberOfGenesPerChromosome {
+		child = append(child, parentGenes[i:i+numberOfGenesPerChromosome]...)
+	}
+	return append(child, parentGenes[max:]...)
+}
+
+// shiftGenes moves the first or last chromosome of a run to the other end
+// of the run.
+func shiftGenes(child, parentGenes []byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	numberOfChromosomesInParent := len(parentGenes) / numberOfGenesPerChromosome
+	if numberOfChromosomesInParent < 2 {
+		return child
+	}
+	shiftRight := random.Intn(2) == 1
+
+	segmentStart := random.Intn(numberOfChromosomesInParent - 1)
+	if segmentStart > 0 {
+		segmentStart--
+	}
+	segmentCount := 2
+	if numberOfChromosomesInParent > 2+segmentStart {
+		segmentCount = 2 + random.Intn(numberOfChromosomesInParent-(1+segmentStart))
+	}
+
+	segmentOffset := numberOfGenesPerChromosome * segmentStart
+	segmentLength := numberOfGenesPerChromosome * segmentCount
+
+	child = append(child, parentGenes[:segmentOffset]...)
+	if shiftRight {
+		child = append(child, parentGenes[segmentOffset+segmentLength-numberOfGenesPerChromosome:segmentOffset+segmentLength]...)
+		child = append(child, parentGenes[segmentOffset:segmentOffset+segmentLength-numberOfGenesPerChromosome]...)
+	} else {
+		child = append(child, parentGenes[segmentOffset+numberOfGenesPerChromosome:segmentOffset+segmentLength]...)
+		child = append(child, parentGenes[segmentOffset:segmentOffset+numberOfGenesPerChromosome]...)
+	}
+	return append(child, parentGenes[segmentOffset+segmentLength:]...)
+}
+
+// swapGenes exchanges two genes or two chromosomes.
+func swapGenes(child, parentGenes []byte, numberOfGenesPerChromosome int, random RandomSource) []byte {
+	swapLength := numberOfGenesPerChromosome
+	if random.Intn(2) == 0 {
+		swapLength = 1
+	}
+
+	if len(parentGenes) == swapLength {
+		return child
+	}
+
+	parentIndexA := random.Intn(len(parentGenes)/swapLength) * swapLength
+	parentIndexB := random.Intn(len(parentGenes)/swapLength) * swapLength
+	if parentIndexA == parentIndexB {
+		parentIndexB += swapLength
+		parentIndexB %= len(parentGenes)
+	}
+
+	parentIndexA, parentIndexB = sort(parentIndexA, parentIndexB)
+
+	child = append(child, parentGenes[:parentIndexA]...)
+	child = append(child, parentGenes[parentIndexB:parentIndexB+swapLength]...)
+	child = append(child, parentGenes[parentIndexA+swapLength:parentIndexB]...)
+	child = append(child, parentGenes[parentIndexA:parentIndexA+swapLength]...)
+	return append(child, parentGenes[parentIndexB+swapLength:]...)
+}
+
+func chooseWeightedChromosome(lenParentGenes, numberOfGenesPerChromosome int, random RandomSource) int {
+	// prefer chromosomes near the end
+	numberOfChromosomes := lenParentGenes / numberOfGenesPerChromosome
+	index := lenParentGenes - numberOfGenesPerChromosome
+	for ; index > 0 && random.Intn(numberOfChromosomes) != 0; numberOfChromosomes, index = numberOfChromosomes-1, index-numberOfGenesPerChromosome {
+	}
+	return index
+}
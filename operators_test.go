@@ -0,0 +1,71 @@
+package genetic
+
+import (
+	"testing"
+)
+
+// BenchmarkStrategies builds children the way the strategy goroutines do,
+// reusing the buffer of a child that was thrown away, so allocs/op is
+// what each strategy costs per child once recycling has warmed up.
+func BenchmarkStrategies(b *testing.B) {
+	const geneSet, numberOfGenesPerChromosome = "abcdefghijklmnopqrstuvwxyz", 4
+	random := createSeededRandomNumberGenerators(1)()
+	genes := newGeneGenerator(geneSet, numberOfGenesPerChromosome, random)
+	parentA, parentB := genes.parent(25), genes.parent(25)
+
+	strategies := []struct {
+		name  string
+		apply func(child []byte) []byte
+	}{
+		{"add", func(child []byte) []byte {
+			return addGenes(child, parentA, parentB, numberOfGenesPerChromosome)
+		}},
+		{"crossover", func(child []byte) []byte {
+			return crossoverGenes(child, parentA, parentB, numberOfGenesPerChromosome, random)
+		}},
+		{"duplicate", func(child []byte) []byte {
+			return duplicateGenes(child, parentA, numberOfGenesPerChromosome, random)
+		}},
+		{"flutter", func(child []byte) []byte {
+			return flutterGenes(child, parentA, geneSet, numberOfGenesPerChromosome, random)
+		}},
+		{"insert", func(child []byte) []byte {
+			return insertGenes(child, parentA, genes.gene, numberOfGenesPerChromosome, random)
+		}},
+		{"mutate", func(child []byte) []byte {
+			return mutateGenes(child, parentA, genes.gene, random)
+		}},
+		{"random", func(child []byte) []byte {
+			return randomGenes(child, len(parentA), numberOfGenesPerChromosome, genes.gene)
+		}},
+		{"remove", func(child []byte) []byte {
+			return removeGenes(child, parentA, numberOfGenesPerChromosome, random)
+		}},
+		{"replace", func(child []byte) []byte {
+			return replaceGenes(child, parentA, genes.gene, numberOfGenesPerChromosome, random)
+		}},
+		{"reverse", func(child []byte) []byte {
+			return reverseGenes(child, parentA, numberOfGenesPerChromosome, random)
+		}},
+		{"shift", func(child []byte) []byte {
+			return shiftGenes(child, parentA, numberOfGenesPerChromosome, random)
+		}},
+		{"swap", func(child []byte) []byte {
+			return swapGenes(child, parentA, numberOfGenesPerChromosome, random)
+		}},
+		{"transplant", func(child []byte) []byte {
+			return transplantGenes(child, parentA, parentB, numberOfGenesPerChromosome, random)
+		}},
+	}
+	for _, strategy := range strategies {
+		apply := strategy.apply
+		b.Run(strategy.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				child := newChild(len(parentA) + numberOfGenesPerChromosome)
+				child.genes = apply(child.genes)
+				discard(child)
+			}
+		})
+	}
+}
@@ -1,86 +1,149 @@
 package genetic
 
+import (
+	"hash/maphash"
+	"sync"
+)
+
+// pool holds the best distinct sequences found, best first. Its goroutine
+// adds items from addNewItem while other goroutines read, so lock guards
+// items and the maps; methods that only read take the read lock and
+// return copies or single items, never the items slice itself.
 type pool struct {
-	random                randomSource
+	random                RandomSource
+	lock                  sync.RWMutex
 	items                 []*sequenceInfo
-	distinctItems         map[string]bool
+	distinctItems         map[uint64]bool
+	seed                  maphash.Seed
 	distinctItemFitnesses map[int]bool
 	addNewItem            chan *sequenceInfo
+	lifetime              *lifetime
+	niches                *nicheSettings
+	nicheCounts           map[*sequenceInfo]float64
+
+	childFitnessIsSameOrBetter func(*sequenceInfo, *sequenceInfo) bool
 
 	maxPoolSize int
 }
 
 func NewPool(maxPoolSize int,
-	quit chan bool,
+	life *lifetime,
 	childFitnessIsSameOrBetter func(*sequenceInfo, *sequenceInfo) bool,
 	display chan *sequenceInfo) *pool {
-	p := pool{
-		maxPoolSize: maxPoolSize,
+	return newPool(maxPoolSize, life, childFitnessIsSameOrBetter, display, nil, createRandomNumberGenerator())
+}
 
-		random:                createRandomNumberGenerator(),
-		items:                 make([]*sequenceInfo, 0, maxPoolSize),
-		distinctItems:         make(map[string]bool, maxPoolSize),
-		distinctItemFitnesses: make(map[int]bool, maxPoolSize),
-		addNewItem:            make(chan *sequenceInfo, maxPoolSize),
-	}
+// newPool creates a pool served by a goroutine that lives as long as life.
+func newPool(maxPoolSize int,
+	life *lifetime,
+	childFitnessIsSameOrBetter func(*sequenceInfo, *sequenceInfo) bool,
+	display chan *sequenceInfo,
+	niches *nicheSettings,
+	random RandomSource) *pool {
+	p := makePool(maxPoolSize, childFitnessIsSameOrBetter, niches, random)
+	p.lifetime = life
 
-	go func() {
+	life.start(func() {
 		for {
 			select {
-			case <-quit:
-				quit <- true
+			case <-life.done:
 				return
 			case newItem := <-p.addNewItem:
-				if p.distinctItems[newItem.genes] {
-					continue
-				}
-				p.distinctItems[newItem.genes] = true
-
-				if len(p.items) < 1 {
-					p.items = append(p.items, newItem)
-				} else if childFitnessIsSameOrBetter(newItem, p.items[0]) {
-					if newItem.fitness != p.items[0].fitness {
-						go func() { display <- newItem }()
-					}
-					if len(p.items) < maxPoolSize {
-						p.items = append(p.items, newItem)
-					} else {
-						p.items[0], p.items[len(p.items)-1] = newItem, p.items[0]
-					}
-					insertionSort(p.items, childFitnessIsSameOrBetter, len(p.items)-1)
-				} else if len(p.items) < maxPoolSize {
-					p.items = append(p.items, newItem)
-					insertionSort(p.items, childFitnessIsSameOrBetter, len(p.items)-1)
-				} else if childFitnessIsSameOrBetter(newItem, p.items[len(p.items)-1]) {
-					p.items[len(p.items)-1] = newItem
-					insertionSort(p.items, childFitnessIsSameOrBetter, len(p.items)-1)
-				} else if len(p.distinctItemFitnesses) < 4 {
-					p.items[len(p.items)-1] = newItem
-					insertionSort(p.items, childFitnessIsSameOrBetter, len(p.items)-1)
-				} else {
-					continue
+				if p.offer(newItem) {
+					life.send(display, newItem)
 				}
-
-				p.distinctItemFitnesses[newItem.fitness] = true
 			}
 		}
-	}()
+	})
 
-	return &p
+	return p
+}
+
+// makePool creates a pool that items can be offered to directly, without
+// the goroutine that serves addNewItem.
+func makePool(maxPoolSize int,
+	childFitnessIsSameOrBetter func(*sequenceInfo, *sequenceInfo) bool,
+	niches *nicheSettings,
+	random RandomSource) *pool {
+	return &pool{
+		maxPoolSize: maxPoolSize,
+		niches:      niches,
+		nicheCounts: make(map[*sequenceInfo]float64),
+
+		childFitnessIsSameOrBetter: childFitnessIsSameOrBetter,
+
+		random:                random,
+		items:                 make([]*sequenceInfo, 0, maxPoolSize),
+		distinctItems:         make(map[uint64]bool, maxPoolSize),
+		seed:                  maphash.MakeSeed(),
+		distinctItemFitnesses: make(map[int]bool, maxPoolSize),
+		addNewItem:            make(chan *sequenceInfo, maxPoolSize),
+	}
+}
+
+// offer puts newItem in its place in the pool, if it earns one, and
+// reports whether it is better than the previous best.
+func (p *pool) offer(newItem *sequenceInfo) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	key := p.key(newItem.genes)
+	if p.distinctItems[key] {
+		return false
+	}
+	p.distinctItems[key] = true
+
+	isNewBest := false
+	if p.niches != nil {
+		isNewBest = len(p.items) > 0 &&
+			p.childFitnessIsSameOrBetter(newItem, p.items[0]) &&
+			newItem.fitness != p.items[0].fitness
+		if !p.addWithNiching(newItem) {
+			return false
+		}
+	} else if len(p.items) < 1 {
+		p.items = append(p.items, newItem)
+	} else if p.childFitnessIsSameOrBetter(newItem, p.items[0]) {
+		isNewBest = newItem.fitness != p.items[0].fitness
+		if len(p.items) < p.maxPoolSize {
+			p.items = append(p.items, newItem)
+		} else {
+			p.items[0], p.items[len(p.items)-1] = newItem, p.items[0]
+		}
+		insertionSort(p.items, p.childFitnessIsSameOrBetter, len(p.items)-1)
+	} else if len(p.items) < p.maxPoolSize {
+		p.items = append(p.items, newItem)
+		insertionSort(p.items, p.childFitnessIsSameOrBetter, len(p.items)-1)
+	} else if p.childFitnessIsSameOrBetter(newItem, p.items[len(p.items)-1]) {
+		p.items[len(p.items)-1] = newItem
+		insertionSort(p.items, p.childFitnessIsSameOrBetter, len(p.items)-1)
+	} else if len(p.distinctItemFitnesses) < 4 {
+		p.items[len(p.items)-1] = newItem
+		insertionSort(p.items, p.childFitnessIsSameOrBetter, len(p.items)-1)
+	} else {
+		return false
+	}
+
+	p.distinctItemFitnesses[newItem.fitness] = true
+	return isNewBest
 }
 
 func (p *pool) addAll(items []*sequenceInfo) {
 	for _, item := range items {
-		p.addNewItem <- item
+		select {
+		case p.addNewItem <- item:
+		case <-p.lifetime.done:
+			return
+		}
 	}
 }
 
 func (p *pool) addItem(item *sequenceInfo) {
-	go func() { p.addNewItem <- item }()
+	p.lifetime.send(p.addNewItem, item)
 }
 
 func (p *pool) any() bool {
-	return len(p.items) > 0
+	return p.len() > 0
 }
 
 func (p *pool) cap() int {
@@ -88,66 +151,105 @@ func (p *pool) cap() int {
 }
 
 func (p *pool) contains(item *sequenceInfo) bool {
-	return p.distinctItems[item.genes]
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.distinctItems[p.key(item.genes)]
+}
+
+// key identifies genes in distinctItems by a hash instead of a copy of
+// them; two genomes in one pool sharing a 64-bit hash is too unlikely to
+// matter.
+func (p *pool) key(genes []byte) uint64 {
+	return maphash.Bytes(p.seed, genes)
 }
 
 func (p *pool) getBest() *sequenceInfo {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
 	return p.items[0]
 }
 
-func (p *pool) getRandomItem() (ret *sequenceInfo) {
-	index := p.random.Intn(len(p.items))
-	defer func() {
-		if r := recover(); r != nil {
-			ret = p.items[0]
-		}
-	}()
-
-	ret = p.items[index]
-	return
+// getRandomItem expects the lock to be held.
+func (p *pool) getRandomItem() *sequenceInfo {
+	return p.items[p.random.Intn(len(p.items))]
 }
 
 func (p *pool) getWorst() *sequenceInfo {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
 	return p.items[len(p.items)-1]
 }
 
 func (p *pool) len() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
 	return len(p.items)
 }
 
-func (p *pool) populatePool(nextChromosome chan string, geneSet string, numberOfChromosomes, numberOfGenesPerChromosome int, compareFitnesses func(*sequenceInfo, *sequenceInfo) bool, getFitness func(string) int, initialParent *sequenceInfo) {
+// lengthHistogram counts the items by number of chromosomes.
+func (p *pool) lengthHistogram(numberOfGenesPerChromosome int) map[int]int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	lengths := make(map[int]int)
+	for _, item := range p.items {
+		lengths[len(item.genes)/max(1, numberOfGenesPerChromosome)]++
+	}
+	return lengths
+}
+
+// snapshot returns a copy of the items, best first.
+func (p *pool) snapshot() []*sequenceInfo {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return append([]*sequenceInfo(nil), p.items...)
+}
+
+func (p *pool) populatePool(newGenes func() []byte, evaluate func(*sequenceInfo), initialParent *sequenceInfo, seeds []*sequenceInfo) {
 
-	itemGenes := generateParent(nextChromosome, geneSet, numberOfChromosomes, numberOfGenesPerChromosome)
 	initialStrategy := strategyInfo{name: "initial   "}
 	p.addItem(initialParent)
+	p.addAll(seeds)
 
 	max := p.cap()
 	for i := 0; i < 2*max; i++ {
-		itemGenes = generateParent(nextChromosome, geneSet, numberOfChromosomes, numberOfGenesPerChromosome)
-		sequence := sequenceInfo{genes: itemGenes, fitness: getFitness(itemGenes), strategy: initialStrategy}
+		itemGenes := newGenes()
+		sequence := sequenceInfo{genes: itemGenes, strategy: initialStrategy}
 		sequence.parent = &sequence
-		p.addNewItem <- &sequence
+		evaluate(&sequence)
+		p.addAll([]*sequenceInfo{&sequence})
 	}
 }
 
 func (p *pool) reset(item *sequenceInfo) {
-	p.items = p.items[:1]
-	p.resetDistinct()
-	p.addNewItem <- item
+	p.truncateAndAddAllTo(1, []*sequenceInfo{item})
 }
 
+// resetDistinct expects the lock to be held.
 func (p *pool) resetDistinct() {
-	p.distinctItems = make(map[string]bool, p.maxPoolSize)
+	p.distinctItems = make(map[uint64]bool, p.maxPoolSize)
 	p.distinctItemFitnesses = make(map[int]bool, p.maxPoolSize)
 
 	for i := 0; i < len(p.items); i++ {
-		p.distinctItems[p.items[i].genes] = true
+		p.distinctItems[p.key(p.items[i].genes)] = true
 		p.distinctItemFitnesses[p.items[i].fitness] = true
 	}
+	p.resetNicheCounts()
 }
 
 func (p *pool) truncateAndAddAll(items []*sequenceInfo) {
-	p.items = p.items[:min(20, len(p.items))]
-	p.resetDistinct()
+	p.truncateAndAddAllTo(20, items)
+}
+
+func (p *pool) truncateAndAddAllTo(length int, items []*sequenceInfo) {
+	p.truncate(length)
 	p.addAll(items)
 }
+
+// truncate keeps the best length items and forgets the rest, so they can
+// be added again.
+func (p *pool) truncate(length int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.items = p.items[:min(length, len(p.items))]
+	p.resetDistinct()
+}
@@ -0,0 +1,267 @@
+package genetic_test
+
+import (
+	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/genetictest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestPoolKeepsDistinctItemsBestFirst(t *testing.T) {
+	pool := genetic.NewTestPool(3, genetictest.NewScript(t))
+
+	if pool.Add("a", 1) {
+		t.Error("the first item has nothing to improve on")
+	}
+	if !pool.Add("b", 3) {
+		t.Error("expected b to be a new best")
+	}
+	if pool.Add("b", 5) {
+		t.Error("expected a duplicate to be ignored")
+	}
+	if pool.Add("c", 3) {
+		t.Error("expected an equal fitness not to be a new best")
+	}
+	pool.Add("d", 2)
+
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"c", "b", "d"}) {
+		t.Errorf("expected [c b d], got %v", genes)
+	}
+}
+
+func TestPoolForgetsItemsDroppedByTruncation(t *testing.T) {
+	pool := genetic.NewTestPool(4, genetictest.NewScript(t))
+	pool.Add("a", 4)
+	pool.Add("b", 3)
+	pool.Add("c", 2)
+
+	pool.TruncateTo(1)
+	pool.Add("c", 2)
+
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"a", "c"}) {
+		t.Errorf("expected [a c], got %v", genes)
+	}
+}
+
+func TestPoolSelection(t *testing.T) {
+	fill := func(random genetic.RandomSource) genetic.TestPool {
+		pool := genetic.NewTestPool(4, random)
+		for i, genes := range []string{"a", "b", "c", "d"} {
+			pool.Add(genes, 10-i)
+		}
+		return pool
+	}
+
+	tests := []struct {
+		name     string
+		scheme   genetic.SelectionScheme
+		script   []int
+		expected string
+	}{
+		{"uniform", genetic.UniformSelection, []int{2}, "c"},
+		{"tournament keeps the best drawn", genetic.TournamentSelection, []int{3, 1, 2}, "b"},
+		{"linear rank, lowest draw is the worst", genetic.LinearRankSelection, []int{0}, "d"},
+		{"linear rank, highest draw is the best", genetic.LinearRankSelection, []int{9}, "a"},
+		{"roulette", genetic.RouletteSelection, []int{4}, "b"},
+		{"truncation draws from the top half", genetic.TruncationSelection, []int{1}, "b"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			random := genetictest.NewScript(t, test.script...)
+			if genes := fill(random).Select(test.scheme, 3, 50); genes != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, genes)
+			}
+			if random.Remaining() != 0 {
+				t.Errorf("expected the whole script to be used, %d left", random.Remaining())
+			}
+		})
+	}
+}
+
+func TestDoubleTournamentSelectionFavorsShorterEntrants(t *testing.T) {
+	random := genetictest.NewScript(t,
+		0, 2, 10, // c is shorter than aaaa and wins the first parsimony tournament
+		1, 3, 90) // bb is shorter than dddd but loses the second
+	pool := genetic.NewTestPool(4, random)
+	for i, genes := range []string{"aaaa", "bb", "c", "dddd"} {
+		pool.Add(genes, 10-i)
+	}
+
+	if genes := pool.SelectParsimoniously(2, 70); genes != "c" {
+		t.Errorf("expected c, the fitter of c and dddd, got %s", genes)
+	}
+	if random.Remaining() != 0 {
+		t.Errorf("expected the whole script to be used, %d left", random.Remaining())
+	}
+}
+
+func TestCrowdingChildrenReplaceTheirParentOrNearestOnlyIfAsGood(t *testing.T) {
+	pool := genetic.NewTestNichePool(3, genetic.DeterministicCrowding, 0, 0)
+	pool.Add("aaaa", 10)
+	pool.Add("bbbb", 8)
+	pool.Add("cccc", 6)
+
+	pool.AddChild("bbbx", 7, "bbbb")
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaaa", "bbbb", "cccc"}) {
+		t.Errorf("expected a child worse than its parent to be left out, got %v", genes)
+	}
+
+	pool.AddChild("bbby", 9, "bbbb")
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaaa", "bbby", "cccc"}) {
+		t.Errorf("expected bbby to replace its parent, got %v", genes)
+	}
+
+	pool.AddChild("ccca", 7, "gone")
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaaa", "bbby", "ccca"}) {
+		t.Errorf("expected ccca to replace the nearest item, got %v", genes)
+	}
+}
+
+func TestSharingReplacesTheHighestSharedRankButNeverTheBest(t *testing.T) {
+	pool := genetic.NewTestNichePool(4, genetic.FitnessSharing, 3, 0)
+	pool.Add("aaaa", 10)
+	pool.Add("aaab", 9)
+	pool.Add("aaba", 8)
+	pool.Add("zzzz", 7)
+
+	// shared ranks are 2*2 for aaab, 3*2 for aaba, 4*1 for zzzz and 5*1
+	// for yyyy
+	pool.Add("yyyy", 1)
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaaa", "aaab", "zzzz", "yyyy"}) {
+		t.Errorf("expected yyyy to replace aaba, got %v", genes)
+	}
+
+	pool = genetic.NewTestNichePool(1, genetic.FitnessSharing, 3, 0)
+	pool.Add("aaaa", 10)
+	pool.Add("zzzz", 5)
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaaa"}) {
+		t.Errorf("expected the best to be kept, got %v", genes)
+	}
+}
+
+func TestClearingKeepsNichesWithinCapacity(t *testing.T) {
+	pool := genetic.NewTestNichePool(4, genetic.Clearing, 2, 2)
+	pool.Add("aaaa", 10)
+	pool.Add("aaab", 9)
+	pool.Add("zzzz", 5)
+
+	pool.Add("aaac", 8)
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaaa", "aaab", "zzzz"}) {
+		t.Errorf("expected aaac to be left out of the full niche, got %v", genes)
+	}
+
+	pool.Add("aaad", 12)
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaad", "aaaa", "zzzz"}) {
+		t.Errorf("expected aaad to replace the worst of its niche, got %v", genes)
+	}
+}
+
+func TestClearingKeepsTheOnlyItemOverAWorseNewcomer(t *testing.T) {
+	pool := genetic.NewTestNichePool(1, genetic.Clearing, 2, 1)
+	pool.Add("aaaa", 10)
+
+	pool.Add("zzzz", 5)
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"aaaa"}) {
+		t.Errorf("expected aaaa to be kept, got %v", genes)
+	}
+
+	pool.Add("yyyy", 15)
+	if genes := pool.Genes(); !reflect.DeepEqual(genes, []string{"yyyy"}) {
+		t.Errorf("expected yyyy to replace aaaa, got %v", genes)
+	}
+}
+
+func TestPoolIsSafeForConcurrentUse(t *testing.T) {
+	pool := genetic.NewTestPool(50, nil)
+	pool.Add("seed", 0)
+
+	var running sync.WaitGroup
+	for writer := 0; writer < 4; writer++ {
+		running.Add(1)
+		go func(writer int) {
+			defer running.Done()
+			for i := 0; i < 500; i++ {
+				pool.Add(strconv.Itoa(writer*1000+i), (writer*7+i*13)%97)
+				if i%100 == 99 {
+					pool.TruncateTo(20)
+				}
+			}
+		}(writer)
+	}
+	for reader := 0; reader < 4; reader++ {
+		running.Add(1)
+		go func(scheme genetic.SelectionScheme) {
+			defer running.Done()
+			for i := 0; i < 500; i++ {
+				if genes := pool.Select(scheme, 3, 50); genes == "" {
+					t.Error("expected a selection from a pool that is never empty")
+					return
+				}
+				pool.Best()
+				pool.Contains("seed")
+			}
+		}(genetic.SelectionScheme(reader))
+	}
+	running.Wait()
+
+	fitnesses := pool.Fitnesses()
+	for i := 1; i < len(fitnesses); i++ {
+		if fitnesses[i-1] < fitnesses[i] {
+			t.Fatalf("expected the pool to be best first, got %v", fitnesses)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, genes := range pool.Genes() {
+		if seen[genes] {
+			t.Fatalf("expected distinct genes, %s is there twice", genes)
+		}
+		seen[genes] = true
+	}
+}
+
+// BenchmarkPoolSelectWhileAdding selects parents, as the strategies do,
+// while one in ten operations adds a child. Adds are made one at a time,
+// as the pool's own goroutine makes them, so the same workload also ran
+// on the unsynchronised pool the lock replaced; compare the two with
+// benchstat across that change. serial is the cost of an operation,
+// parallel shows readers sharing the lock.
+func BenchmarkPoolSelectWhileAdding(b *testing.B) {
+	setUp := func() func(i int) {
+		pool := genetic.NewTestPool(500, nil)
+		for i := 0; i < 500; i++ {
+			pool.Add(strconv.Itoa(i), i)
+		}
+		var adding sync.Mutex
+		added := 500
+		operate := func(i int) {
+			if i%10 == 0 {
+				adding.Lock()
+				added++
+				pool.Add(strconv.Itoa(added), added%1000)
+				adding.Unlock()
+				return
+			}
+			pool.Select(genetic.TournamentSelection, 3, 50)
+		}
+		return operate
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		operate := setUp()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			operate(i)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		operate := setUp()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				operate(i)
+			}
+		})
+	})
+}
@@ -0,0 +1,162 @@
+// Package problems holds small, well-understood problems for comparing
+// solver settings, along with the plumbing to run any Problem.
+package problems
+
+import (
+	"fmt"
+	genetic "github.com/handcraftsman/GeneticGo"
+	s "sort"
+	"strconv"
+	"strings"
+)
+
+// Problem describes everything the solver needs to search for a solution.
+type Problem struct {
+	Name                       string
+	GeneSet                    string
+	NumberOfChromosomes        int // the maximum when hill climbing
+	NumberOfGenesPerChromosome int
+	GetFitness                 func(genes string) int
+	LowerFitnessesAreBetter    bool
+	HillClimbing               bool
+	TargetFitness              int
+}
+
+// Solve runs the solver on the problem and returns the best genes found.
+func (problem Problem) Solve(solver *genetic.Solver, display func(genes string)) string {
+	solver.LowerFitnessesAreBetter = problem.LowerFitnessesAreBetter
+	if problem.HillClimbing {
+		return solver.GetBestUsingHillClimbing(problem.GetFitness, display, problem.GeneSet,
+			problem.NumberOfChromosomes, problem.NumberOfGenesPerChromosome, problem.TargetFitness)
+	}
+	return solver.GetBest(problem.GetFitness, display, problem.GeneSet,
+		problem.NumberOfChromosomes, problem.NumberOfGenesPerChromosome)
+}
+
+// ReachedTarget reports whether the fitness is at least as good as the
+// problem's target.
+func (problem Problem) ReachedTarget(fitness int) bool {
+	if fitness < 0 && problem.HillClimbing {
+		return false
+	}
+	if problem.LowerFitnessesAreBetter {
+		return fitness <= problem.TargetFitness
+	}
+	return fitness >= problem.TargetFitness
+}
+
+var builtIn = map[string]func(parameter string) (Problem, error){
+	"string-duplication": func(parameter string) (Problem, error) {
+		if parameter == "" {
+			parameter = "Not all those who wander are lost."
+		}
+		return StringDuplication(parameter), nil
+	},
+	"queens": func(parameter string) (Problem, error) {
+		size := 8
+		if parameter != "" {
+			var err error
+			if size, err = strconv.Atoi(parameter); err != nil || size < 4 || size > 10 {
+				return Problem{}, fmt.Errorf("queens needs a board size from 4 to 10, got %q", parameter)
+			}
+		}
+		return Queens(size), nil
+	},
+	"onemax": func(parameter string) (Problem, error) {
+		length := 100
+		if parameter != "" {
+			var err error
+			if length, err = strconv.Atoi(parameter); err != nil || length < 1 {
+				return Problem{}, fmt.Errorf("onemax needs a positive length, got %q", parameter)
+			}
+		}
+		return OneMax(length), nil
+	},
+}
+
+// Names returns the names accepted by Lookup.
+func Names() []string {
+	names := make([]string, 0, len(builtIn))
+	for name := range builtIn {
+		names = append(names, name)
+	}
+	s.Strings(names)
+	return names
+}
+
+// Lookup returns the built-in problem with the given name. An optional
+// parameter follows a colon, e.g. "queens:6" or "string-duplication:hello".
+func Lookup(nameAndParameter string) (Problem, error) {
+	name, parameter, _ := strings.Cut(nameAndParameter, ":")
+	create, exists := builtIn[name]
+	if !exists {
+		return Problem{}, fmt.Errorf("unknown problem %q, expected one of %v", name, Names())
+	}
+	return create(parameter)
+}
+
+// StringDuplication evolves a copy of the target string.
+func StringDuplication(target string) Problem {
+	geneSet := " abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ!.,'?"
+	for _, gene := range target {
+		if !strings.ContainsRune(geneSet, gene) {
+			geneSet += string(gene)
+		}
+	}
+	return Problem{
+		Name:                       "string-duplication",
+		GeneSet:                    geneSet,
+		NumberOfChromosomes:        len(target),
+		NumberOfGenesPerChromosome: 1,
+		GetFitness: func(genes string) int {
+			return len(target) - genetic.HammingDistance(target, genes)
+		},
+		TargetFitness: len(target),
+	}
+}
+
+// Queens places size queens, one x,y pair of genes each, so that none can
+// attack another.
+func Queens(size int) Problem {
+	geneSet := "0123456789"[:size]
+	return Problem{
+		Name:                       "queens",
+		GeneSet:                    geneSet,
+		NumberOfChromosomes:        size,
+		NumberOfGenesPerChromosome: 2,
+		GetFitness: func(genes string) int {
+			safeQueens := 0
+			for i := 0; i < len(genes); i += 2 {
+				x, y := int(genes[i]), int(genes[i+1])
+				isSafe := true
+				for j := 0; j < len(genes) && isSafe; j += 2 {
+					if i == j {
+						continue
+					}
+					otherX, otherY := int(genes[j]), int(genes[j+1])
+					isSafe = x != otherX && y != otherY &&
+						x-otherX != y-otherY && x-otherX != otherY-y
+				}
+				if isSafe {
+					safeQueens++
+				}
+			}
+			return safeQueens
+		},
+		TargetFitness: size,
+	}
+}
+
+// OneMax evolves a string of length ones.
+func OneMax(length int) Problem {
+	return Problem{
+		Name:                       "onemax",
+		GeneSet:                    "01",
+		NumberOfChromosomes:        length,
+		NumberOfGenesPerChromosome: 1,
+		GetFitness: func(genes string) int {
+			return strings.Count(genes, "1")
+		},
+		TargetFitness: length,
+	}
+}
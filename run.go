@@ -0,0 +1,343 @@
+package genetic
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// run holds the state of a single GetBest or GetBestUsingHillClimbing
+// call, leaving the Solver it came from untouched.
+type run struct {
+	solver                            *Solver
+	maxSecondsToRunWithoutImprovement float64
+	maxRoundsWithoutImprovement       int
+	initialParent                     sequenceInfo
+	seeds                             []sequenceInfo
+	stats                             *runStatistics
+	stopping                          stopGate
+	logger                            *slog.Logger
+	emit                              func(Event)
+
+	childFitnessIsBetter, childFitnessIsSameOrBetter func(child, other *sequenceInfo) bool
+
+	// only the goroutine displaying improvements changes these while
+	// the run is in progress
+	strategies                     map[string]*strategyInfo
+	successParentIsBestParentCount int
+	numberOfImprovements           int
+}
+
+func (solver *Solver) newRun(childFitnessIsBetter, childFitnessIsSameOrBetter func(child, other *sequenceInfo) bool) *run {
+	run := run{
+		solver:                            solver,
+		maxSecondsToRunWithoutImprovement: solver.MaxSecondsToRunWithoutImprovement,
+		maxRoundsWithoutImprovement:       solver.MaxRoundsWithoutImprovement,
+		stats:                             newRunStatistics(),
+		logger:                            solver.logger(),
+		strategies:                        make(map[string]*strategyInfo, 10),
+		childFitnessIsBetter:              childFitnessIsBetter,
+		childFitnessIsSameOrBetter:        childFitnessIsSameOrBetter,
+	}
+	run.stopping.reset()
+	run.emit = solver.createEmitter(run.stats)
+
+	if run.maxRoundsWithoutImprovement == 0 {
+		run.maxRoundsWithoutImprovement = 2
+	}
+	if run.maxSecondsToRunWithoutImprovement == 0 {
+		run.maxSecondsToRunWithoutImprovement = 20
+		run.logger.Info("defaulted MaxSecondsToRunWithoutImprovement",
+			"seconds", run.maxSecondsToRunWithoutImprovement)
+	}
+
+	if solver.LowerFitnessesAreBetter {
+		run.initialParent.fitness = math.MaxInt32
+	} else {
+		run.initialParent.fitness = math.MinInt32
+	}
+	run.initialParent.parent = &run.initialParent
+
+	return &run
+}
+
+// seed scores the seeds given to the solver that suit the problem, and
+// starts the run from the best of them.
+func (run *run) seed(getFitness func([]byte) int,
+	geneSet string,
+	numberOfGenesPerChromosome, minNumberOfChromosomes, maxNumberOfChromosomes int) {
+
+	seeds := run.solver.takeSeeds()
+	run.seeds = make([]sequenceInfo, 0, len(seeds))
+	seedStrategy := strategyInfo{name: "seed      "}
+	for _, genes := range seeds {
+		if problem := checkSeed(genes, geneSet, numberOfGenesPerChromosome, minNumberOfChromosomes, maxNumberOfChromosomes); problem != "" {
+			run.logger.Warn("seed left out",
+				"seed", genes,
+				"problem", problem)
+			continue
+		}
+		seed := sequenceInfo{genes: []byte(genes), strategy: seedStrategy}
+		seed.fitness = getFitness(seed.genes)
+		run.seeds = append(run.seeds, seed)
+	}
+
+	for i := range run.seeds {
+		if len(run.initialParent.genes) == 0 || run.childFitnessIsBetter(&run.seeds[i], &run.initialParent) {
+			run.initialParent = run.seeds[i]
+		}
+	}
+	run.initialParent.parent = &run.initialParent
+}
+
+// checkSeed describes what makes genes unusable as a seed, if anything.
+func checkSeed(genes, geneSet string,
+	numberOfGenesPerChromosome, minNumberOfChromosomes, maxNumberOfChromosomes int) string {
+
+	if len(genes)%numberOfGenesPerChromosome != 0 {
+		return fmt.Sprintf("length %d is not a multiple of %d genes per chromosome", len(genes), numberOfGenesPerChromosome)
+	}
+	if numberOfChromosomes := len(genes) / numberOfGenesPerChromosome; numberOfChromosomes < minNumberOfChromosomes || numberOfChromosomes > maxNumberOfChromosomes {
+		if minNumberOfChromosomes == maxNumberOfChromosomes {
+			return fmt.Sprintf("%d chromosomes, expected %d", numberOfChromosomes, minNumberOfChromosomes)
+		}
+		return fmt.Sprintf("%d chromosomes, expected %d to %d", numberOfChromosomes, minNumberOfChromosomes, maxNumberOfChromosomes)
+	}
+	for _, gene := range []byte(genes) {
+		if strings.IndexByte(geneSet, gene) < 0 {
+			return fmt.Sprintf("gene %q is not in the gene set", gene)
+		}
+	}
+	return ""
+}
+
+func (run *run) solve(getFitness func([]byte) int,
+	display func(string),
+	geneSet string,
+	numberOfGenesPerChromosome int,
+	evolve func(e *evolver)) string {
+
+	solver := run.solver
+	stats := run.stats
+	logger := run.logger
+	emit := run.emit
+
+	solver.startRun(run)
+	defer solver.finishRun(run)
+
+	getFitness = stats.countEvaluations(getFitness)
+	if solver.MaxProcs > 0 {
+		getFitness = limitEvaluations(getFitness, solver.MaxProcs)
+	}
+
+	// the display goroutine replaces bestEver while evolvers restart
+	// from it
+	var bestLock sync.Mutex
+	bestEver := run.initialParent
+	getBestEver := func() sequenceInfo {
+		bestLock.Lock()
+		defer bestLock.Unlock()
+		return bestEver
+	}
+	displayCaptureBest := make(chan *sequenceInfo)
+	displaying := newLifetime()
+
+	displaying.start(func() {
+		for {
+			select {
+			case <-displaying.done:
+				return
+			case candidate := <-displayCaptureBest:
+				best := getBestEver()
+				if !run.childFitnessIsBetter(candidate, &best) {
+					continue
+				}
+				if solver.PrintDiagnosticInfo {
+					logger.Info("improvement",
+						"evolver", candidate.evolverId,
+						"strategy", strings.TrimSpace(candidate.strategy.name),
+						"fitness", candidate.fitness,
+						"elapsed", time.Since(stats.start))
+				}
+				genes := string(candidate.genes)
+				stats.recordBest(candidate)
+				display(genes)
+				emit(Event{
+					Kind:     ImprovementEvent,
+					Evolver:  candidate.evolverId,
+					Strategy: strings.TrimSpace(candidate.strategy.name),
+					Fitness:  candidate.fitness,
+					Genes:    genes,
+				})
+
+				run.incrementStrategyUseCount(candidate, &best)
+
+				bestLock.Lock()
+				bestEver = *candidate
+				bestLock.Unlock()
+			}
+		}
+	})
+
+	numberOfParentLines := max(1, solver.NumberOfConcurrentEvolvers)
+	novelty := newNoveltySearch(solver)
+
+	done := make(chan int)
+	startEvolver := func(id int) {
+		for {
+			initialParent := getBestEver()
+			e := evolver{
+				maxSecondsToRunWithoutImprovement: run.maxSecondsToRunWithoutImprovement,
+				maxRoundsWithoutImprovement:       run.maxRoundsWithoutImprovement,
+				lowerFitnessesAreBetter:           solver.LowerFitnessesAreBetter,
+				childFitnessIsBetter:              run.childFitnessIsBetter,
+				childFitnessIsSameOrBetter:        run.childFitnessIsSameOrBetter,
+				geneSet:                           geneSet,
+				numberOfGenesPerChromosome:        numberOfGenesPerChromosome,
+				initialParent:                     initialParent,
+				seeds:                             run.seeds,
+				display:                           displayCaptureBest,
+				getFitness:                        getFitness,
+				id:                                id,
+				niches:                            solver.createNicheSettings(),
+				poolSize:                          solver.getPoolSizeFunc(),
+				selection: selectionSettings{
+					scheme:            solver.Selection,
+					tournamentSize:    solver.TournamentSize,
+					truncationPercent: solver.TruncationPercent,
+					parsimonyPercent:  solver.ParsimonyPercent,
+				},
+				growth: solver.Growth,
+				stagnation: stagnationSettings{
+					response:      solver.StagnationResponse,
+					seconds:       solver.StagnationSeconds,
+					diversity:     solver.StagnationDiversity,
+					eliteCount:    solver.EliteCount,
+					reseedPercent: solver.ReseedPercent,
+				},
+				distance:  distanceBetweenGenes(solver.Distance),
+				novelty:   novelty,
+				stats:     stats,
+				emit:      emit,
+				newRandom: solver.createRandomNumberGenerators(id),
+				stopped:   run.stopping.done(),
+				pause:     &solver.pause,
+			}
+
+			evolve(&e)
+			stats.recordDiversity(id, e.pool.diversity(distanceBetweenGenes(solver.Distance)))
+			stats.recordLengths(id, e.pool.lengthHistogram(numberOfGenesPerChromosome))
+			for _, strategy := range e.strategies {
+				emit(Event{
+					Kind:       StrategySummaryEvent,
+					Evolver:    id,
+					Strategy:   strings.TrimSpace(strategy.name),
+					Selections: strategy.selectionCount,
+					Successes:  strategy.successCount,
+				})
+			}
+
+			if solver.NumberOfConcurrentEvolvers < 2 ||
+				bytes.Equal(initialParent.genes, getBestEver().genes) ||
+				isClosed(run.stopping.done()) {
+				break
+			}
+			stats.recordRestart()
+			emit(Event{Kind: EvolverRestartedEvent, Evolver: id})
+			if solver.PrintDiagnosticInfo {
+				logger.Info("evolver restarting",
+					"evolver", id,
+					"elapsed", time.Since(stats.start))
+			}
+		}
+		done <- id
+	}
+
+	for i := 0; i < numberOfParentLines; i++ {
+		go startEvolver(i + 1)
+	}
+
+	doneCount := 0
+	for {
+		select {
+		case id := <-done:
+			doneCount++
+			if solver.PrintDiagnosticInfo {
+				logger.Info("evolver finished",
+					"evolver", id,
+					"diversity", stats.diversityOf(id),
+					"lengths", stats.lengthsOf(id),
+					"elapsed", time.Since(stats.start))
+			}
+			if doneCount == numberOfParentLines {
+				goto end
+			}
+		}
+	}
+
+end:
+	// every evolver has handed over its best, so the last genes displayed
+	// are the result
+	displaying.stop()
+	stats.finish()
+	run.printStrategyUsage()
+
+	return string(bestEver.genes)
+}
+
+// limitEvaluations lets at most n calls to getFitness run at once.
+func limitEvaluations(getFitness func([]byte) int, n int) func([]byte) int {
+	slots := make(chan struct{}, n)
+	return func(genes []byte) int {
+		slots <- struct{}{}
+		defer func() { <-slots }()
+		return getFitness(genes)
+	}
+}
+
+func (run *run) incrementStrategyUseCount(candidate, bestEver *sequenceInfo) {
+	if bytes.Equal(bestEver.genes, candidate.parent.genes) {
+		run.successParentIsBestParentCount++
+	}
+	run.numberOfImprovements++
+	run.stats.recordImprovement(candidate)
+
+	strategyName := candidate.strategy.name
+	strategy, exists := run.strategies[strategyName]
+	if !exists {
+		strategy = &strategyInfo{name: strategyName}
+		run.strategies[strategyName] = strategy
+	}
+	strategy.successCount++
+}
+
+func (run *run) printStrategyUsage() {
+	if !run.solver.PrintStrategyUsage {
+		return
+	}
+
+	var multiplier = 100
+	numberOfImprovements := run.numberOfImprovements
+	if numberOfImprovements == 0 {
+		numberOfImprovements = 1
+		multiplier = 1
+	}
+	statistics := run.stats.snapshot()
+	for _, strategy := range run.strategies {
+		run.logger.Info("successful strategy usage",
+			"strategy", strings.TrimSpace(strategy.name),
+			"successes", strategy.successCount,
+			"percent", multiplier*strategy.successCount/numberOfImprovements)
+	}
+
+	run.logger.Info("run summary",
+		"championParentIsReigningChampionPercent", multiplier*run.successParentIsBestParentCount/numberOfImprovements,
+		"diversity", statistics.Diversity,
+		"stagnationResponses", statistics.StagnationResponses,
+		"evaluations", statistics.Evaluations,
+		"elapsed", statistics.Elapsed)
+}
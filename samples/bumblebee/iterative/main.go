@@ -48,6 +48,7 @@ func main() {
 	solver.MaxRoundsWithoutImprovement = 3
 	solver.PrintDiagnosticInfo = true
 	solver.NumberOfConcurrentEvolvers = 1// 3
+	solver.Selection = genetic.DoubleTournamentSelection
 //	solver.MaxProcs = 12
 
 	var best = solver.GetBestUsingHillClimbing(calc, disp, geneSet, maxBeeActions, 4, maxFitness)
@@ -34,6 +34,8 @@ func main() {
 	var solver = new(genetic.Solver)
 	solver.MaxSecondsToRunWithoutImprovement = 1
 	solver.MaxRoundsWithoutImprovement = 10
+	solver.Growth = genetic.AppendChromosome | genetic.InsertChromosome | genetic.DuplicateChromosome
+	solver.Selection = genetic.DoubleTournamentSelection
 
 	var best = solver.GetBestUsingHillClimbing(calc, disp, geneSet, maxMowerActions, 1, maxFitness)
 
@@ -34,7 +34,7 @@ func main() {
 	solver.MaxSecondsToRunWithoutImprovement = .5
 	solver.MaxRoundsWithoutImprovement = 3
 
-	var best = solver.GetBestUsingHillClimbing(calc, disp, geneSet, 10, 1, math.MaxInt32)
+	var best = solver.GetBestUsingOpenEndedHillClimbing(calc, disp, geneSet, 10, 1)
 
 	matches, misses := getMatchResults(wanted, unwanted, geneSet, best)
 	if matches == len(wanted) && misses == 0 {
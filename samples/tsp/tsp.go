@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/handcraftsman/File"
 	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/dashboard"
 	"math"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -14,9 +16,10 @@ import (
 const genericGeneSet string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
 
 func main() {
+	dashboardAddress := flag.String("dashboard", "", "serve a live view of the run at this address, e.g. :8080")
 	flag.Parse()
 	if flag.NArg() != 1 {
-		fmt.Println("Usage: go run samples/tsp.go ROUTEFILEPATH")
+		fmt.Println("Usage: go run samples/tsp.go [-dashboard :8080] ROUTEFILEPATH")
 		return
 	}
 	var routeFileName = flag.Arg(0)
@@ -64,6 +67,15 @@ func main() {
 	solver.MaxSecondsToRunWithoutImprovement = 20
 	solver.LowerFitnessesAreBetter = true
 
+	if *dashboardAddress != "" {
+		board := dashboard.New(routeFileName, func(candidate string) string {
+			return renderRoute(candidate, idToPointLookup)
+		})
+		solver.Observers = append(solver.Observers, board)
+		go http.ListenAndServe(*dashboardAddress, board)
+		fmt.Println("dashboard at http://localhost" + *dashboardAddress)
+	}
+
 	var best = solver.GetBest(calc, disp, geneSet, len(idToPointLookup), 1)
 	fmt.Println()
 	fmt.Println(best, "\t", getFitness(best, idToPointLookup))
@@ -112,6 +124,20 @@ func getFitness(candidate string, idToPointLookup map[string]Point) int {
 	return fitness
 }
 
+func renderRoute(candidate string, idToPointLookup map[string]Point) string {
+	points := genesToPoints(candidate, idToPointLookup)
+	minRow, maxRow, minCol, maxCol := points[0].row, points[0].row, points[0].col, points[0].col
+	route := make([]string, len(points))
+	for i, point := range points {
+		minRow, maxRow = min(minRow, point.row), max(maxRow, point.row)
+		minCol, maxCol = min(minCol, point.col), max(maxCol, point.col)
+		route[i] = strconv.Itoa(point.row) + "," + strconv.Itoa(point.col)
+	}
+	return fmt.Sprintf(`<svg width="400" height="400" viewBox="%d %d %d %d">`+
+		`<polygon points="%s" fill="none" stroke="#4a7bd0" vector-effect="non-scaling-stroke" stroke-width="2"/></svg>`,
+		minRow-1, minCol-1, maxRow-minRow+2, maxCol-minCol+2, strings.Join(route, " "))
+}
+
 func getDistance(pointA, pointB Point) int {
 	sideA := float64(pointA.row - pointB.row)
 	sideB := float64(pointA.col - pointB.col)
@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	genetic "github.com/handcraftsman/GeneticGo"
-	"math"
 	"strings"
 	"time"
 )
@@ -36,7 +35,7 @@ func main() {
 	solver.MaxSecondsToRunWithoutImprovement = .1
 	solver.MaxRoundsWithoutImprovement = 2
 
-	var best = solver.GetBestUsingHillClimbing(calc, disp, geneSet, 10, 2, math.MaxInt32)
+	var best = solver.GetBestUsingOpenEndedHillClimbing(calc, disp, geneSet, 10, 2)
 
 	fmt.Println("\nFinal:")
 	disp(best)
@@ -0,0 +1,163 @@
+package genetic
+
+import (
+	"math"
+)
+
+// SelectionScheme decides how parents are drawn from the pool.
+type SelectionScheme int
+
+const (
+	// UniformSelection draws every pool member with equal probability.
+	UniformSelection SelectionScheme = iota
+	// TournamentSelection draws TournamentSize members and keeps the best.
+	TournamentSelection
+	// LinearRankSelection draws members with probability proportional to
+	// their rank, the best being the most likely.
+	LinearRankSelection
+	// RouletteSelection draws members with probability proportional to how
+	// much better their fitness is than the worst member's.
+	RouletteSelection
+	// TruncationSelection draws uniformly from the best TruncationPercent
+	// percent of the pool.
+	TruncationSelection
+	// DoubleTournamentSelection is TournamentSelection whose entrants
+	// are the winners of tournaments between two members that the
+	// shorter one wins ParsimonyPercent percent of the time, keeping the
+	// sequences from growing longer than they need to.
+	DoubleTournamentSelection
+)
+
+var selectionSchemeNames = []string{"uniform", "tournament", "linear-rank", "roulette", "truncation", "double-tournament"}
+
+func (scheme SelectionScheme) String() string {
+	return enumName(selectionSchemeNames, int(scheme))
+}
+
+func (scheme SelectionScheme) MarshalText() ([]byte, error) {
+	return []byte(scheme.String()), nil
+}
+
+func (scheme *SelectionScheme) UnmarshalText(text []byte) error {
+	value, err := parseEnumName(selectionSchemeNames, "SelectionScheme", string(text))
+	*scheme = SelectionScheme(value)
+	return err
+}
+
+type selectionSettings struct {
+	scheme            SelectionScheme
+	tournamentSize    int
+	truncationPercent int
+	parsimonyPercent  int
+}
+
+func (p *pool) selectItem(selection selectionSettings) *sequenceInfo {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	switch selection.scheme {
+	case TournamentSelection:
+		return p.getTournamentWinner(max(2, selection.tournamentSize))
+	case LinearRankSelection:
+		return p.getRankWeightedItem()
+	case RouletteSelection:
+		return p.getFitnessWeightedItem()
+	case TruncationSelection:
+		percent := selection.truncationPercent
+		if percent <= 0 || percent > 100 {
+			percent = 50
+		}
+		return p.getItemFromTop(percent)
+	case DoubleTournamentSelection:
+		percent := selection.parsimonyPercent
+		if percent <= 0 || percent > 100 {
+			percent = 70
+		}
+		return p.getDoubleTournamentWinner(max(2, selection.tournamentSize), percent)
+	}
+	return p.getRandomItem()
+}
+
+func (p *pool) getTournamentWinner(tournamentSize int) *sequenceInfo {
+	items := p.items
+	best := p.random.Intn(len(items))
+	for i := 1; i < tournamentSize; i++ {
+		// items are ordered best to worst
+		best = min(best, p.random.Intn(len(items)))
+	}
+	return items[best]
+}
+
+// getDoubleTournamentWinner holds a fitness tournament between the
+// winners of parsimony tournaments.
+func (p *pool) getDoubleTournamentWinner(tournamentSize, parsimonyPercent int) *sequenceInfo {
+	best := p.getParsimonyTournamentWinner(parsimonyPercent)
+	for i := 1; i < tournamentSize; i++ {
+		// items are ordered best to worst
+		best = min(best, p.getParsimonyTournamentWinner(parsimonyPercent))
+	}
+	return p.items[best]
+}
+
+// getParsimonyTournamentWinner returns the index of the shorter of two
+// random items parsimonyPercent percent of the time, otherwise that of
+// the longer.
+func (p *pool) getParsimonyTournamentWinner(parsimonyPercent int) int {
+	items := p.items
+	shorter, longer := p.random.Intn(len(items)), p.random.Intn(len(items))
+	if len(items[longer].genes) < len(items[shorter].genes) {
+		shorter, longer = longer, shorter
+	}
+	if len(items[shorter].genes) == len(items[longer].genes) || p.random.Intn(100) < parsimonyPercent {
+		return shorter
+	}
+	return longer
+}
+
+func (p *pool) getRankWeightedItem() *sequenceInfo {
+	items := p.items
+	n := len(items)
+	// item i has weight n-i so the total weight is n(n+1)/2
+	r := p.random.Intn(n * (n + 1) / 2)
+	fromWorst := int((math.Sqrt(float64(8*r+1)) - 1) / 2)
+	return items[n-1-min(fromWorst, n-1)]
+}
+
+func (p *pool) getFitnessWeightedItem() *sequenceInfo {
+	items := p.items
+	worst := int64(items[len(items)-1].fitness)
+
+	weights := make([]int64, len(items))
+	var total int64
+	for i, item := range items {
+		weight := int64(item.fitness) - worst
+		if weight < 0 {
+			weight = -weight
+		}
+		weights[i] = weight + 1
+		total += weights[i]
+	}
+	if total > math.MaxInt32 {
+		scale := total/math.MaxInt32 + 1
+		total = 0
+		for i := range weights {
+			weights[i] = weights[i]/scale + 1
+			total += weights[i]
+		}
+	}
+
+	r := int64(p.random.Intn(int(total)))
+	for i, weight := range weights {
+		if r < weight {
+			return items[i]
+		}
+		r -= weight
+	}
+	return items[0]
+}
+
+func (p *pool) getItemFromTop(percent int) *sequenceInfo {
+	items := p.items
+	count := max(1, len(items)*percent/100)
+	return items[p.random.Intn(count)]
+}
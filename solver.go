@@ -1,27 +1,62 @@
 package genetic
 
 import (
-	"fmt"
+	"log/slog"
 	"math"
-	"runtime"
+	"sync"
+	"sync/atomic"
 )
 
+// Solver holds the configuration of a run. The configuration must not be
+// changed while a run is in progress, but otherwise one Solver can run any
+// number of problems, one after another or at the same time.
 type Solver struct {
 	MaxSecondsToRunWithoutImprovement float64
 	MaxRoundsWithoutImprovement       int
+	MaxRoundsWithoutGrowthImprovement int
+	MinNumberOfChromosomes            int
 	LowerFitnessesAreBetter           bool
 	PrintStrategyUsage                bool
 	PrintDiagnosticInfo               bool
 	NumberOfConcurrentEvolvers        int
 	MaxProcs                          int
-
-	initialParentGenes             string
-	initialParent                  sequenceInfo
-	strategies                     map[string]*strategyInfo
-	successParentIsBestParentCount int
-	numberOfImprovements           int
-
-	childFitnessIsBetter, childFitnessIsSameOrBetter func(child, other *sequenceInfo) bool
+	RandomSeed                        int64
+	Logger                            *slog.Logger
+	Observers                         []Observer
+
+	Replacement   ReplacementPolicy
+	Distance      func(a, b string) int
+	NicheRadius   int
+	NicheCapacity int
+
+	PoolSize          int
+	PoolSizeFunc      func(numberOfChromosomes, numberOfGenesPerChromosome, numberOfGenes int) int
+	Selection         SelectionScheme
+	TournamentSize    int
+	TruncationPercent int
+	ParsimonyPercent  int
+
+	Growth        GrowthPolicy
+	PreferShorter bool
+	LengthPenalty float64
+
+	StagnationResponse  StagnationResponse
+	StagnationSeconds   float64
+	StagnationDiversity float64
+	EliteCount          int
+	ReseedPercent       int
+
+	Behavior                func(genes string) []float64
+	NoveltyNeighbors        int
+	NoveltyArchiveThreshold float64
+	NoveltyArchiveSize      int
+	NoveltyFitnessWeight    float64
+
+	lock  sync.Mutex // guards the fields below
+	seeds []string
+	runs  map[*run]bool
+	stats atomic.Pointer[runStatistics]
+	pause pauseGate
 }
 
 func (solver *Solver) GetBest(getFitness func(string) int,
@@ -29,219 +64,252 @@ func (solver *Solver) GetBest(getFitness func(string) int,
 	geneSet string,
 	numberOfChromosomes, numberOfGenesPerChromosome int) string {
 
-	quit := make(chan bool)
-	solver.initialize(getFitness, -1, false)
+	return solver.GetBestBytes(fitnessOfString(getFitness), display, geneSet, numberOfChromosomes, numberOfGenesPerChromosome)
+}
 
-	defer func() {
-		quit <- true
-		solver.initialParentGenes = ""
-	}()
+// GetBestBytes is GetBest for a fitness function that reads the genes as
+// bytes, sparing a copy of them for every evaluation. The slice is only
+// valid during the call and must not be changed.
+func (solver *Solver) GetBestBytes(getFitness func(genes []byte) int,
+	display func(string),
+	geneSet string,
+	numberOfChromosomes, numberOfGenesPerChromosome int) string {
 
-	bestEver := solver.initialParent
-	displayCaptureBest := make(chan *sequenceInfo)
+	run := solver.newRun(createFitnessComparisonFunctions(solver.LowerFitnessesAreBetter, -1, false, solver.lengthPenalty(numberOfGenesPerChromosome)))
+	run.seed(getFitness, geneSet, numberOfGenesPerChromosome, numberOfChromosomes, numberOfChromosomes)
 
-	if solver.MaxProcs > 1 {
-		runtime.GOMAXPROCS(min(solver.MaxProcs, runtime.NumCPU()))
-	}
+	return run.solve(getFitness, display, geneSet, numberOfGenesPerChromosome, func(e *evolver) {
+		e.getBest(numberOfChromosomes, numberOfChromosomes)
+	})
+}
 
-	go func() {
-		for {
-			select {
-			case <-quit:
-				quit <- true
-				return
-			case candidate := <-displayCaptureBest:
-				if !solver.childFitnessIsBetter(candidate, &bestEver) {
-					continue
-				}
-				if solver.PrintDiagnosticInfo {
-					fmt.Print("e ", candidate.evolverId, "\t", candidate.strategy.name)
-				}
-				display(candidate.genes)
+// GetBestOfVariableLength is GetBest for sequences of any length from
+// minNumberOfChromosomes to maxNumberOfChromosomes. The initial pool
+// mixes lengths, the add and remove strategies change them, and the
+// shorter of two equally fit sequences is preferred.
+func (solver *Solver) GetBestOfVariableLength(getFitness func(string) int,
+	display func(string),
+	geneSet string,
+	minNumberOfChromosomes, maxNumberOfChromosomes, numberOfGenesPerChromosome int) string {
 
-				solver.incrementStrategyUseCount(candidate, &bestEver)
+	return solver.GetBestBytesOfVariableLength(fitnessOfString(getFitness), display, geneSet,
+		minNumberOfChromosomes, maxNumberOfChromosomes, numberOfGenesPerChromosome)
+}
 
-				bestEver = *candidate
-			}
-		}
-	}()
-
-	done := make(chan int)
-	startEvolver := func(id int) {
-		for {
-			initialParent := bestEver
-			e := evolver{
-				maxSecondsToRunWithoutImprovement: solver.MaxSecondsToRunWithoutImprovement,
-				maxRoundsWithoutImprovement:       solver.MaxRoundsWithoutImprovement,
-				lowerFitnessesAreBetter:           solver.LowerFitnessesAreBetter,
-				childFitnessIsBetter:              solver.childFitnessIsBetter,
-				childFitnessIsSameOrBetter:        solver.childFitnessIsSameOrBetter,
-				geneSet:                           geneSet,
-				numberOfGenesPerChromosome:        numberOfGenesPerChromosome,
-				initialParent:                     initialParent,
-				display:                           displayCaptureBest,
-				getFitness:                        getFitness,
-				id:                                id,
-			}
-			e.getBest(numberOfChromosomes)
-			if solver.NumberOfConcurrentEvolvers < 2 ||
-				initialParent.genes == bestEver.genes {
-				break
-			}
-			if solver.PrintDiagnosticInfo {
-				fmt.Println("e", id, " restarting")
-			}
-		}
-		done <- id
-	}
+// GetBestBytesOfVariableLength is GetBestOfVariableLength for a fitness
+// function that reads the genes as bytes, as with GetBestBytes.
+func (solver *Solver) GetBestBytesOfVariableLength(getFitness func(genes []byte) int,
+	display func(string),
+	geneSet string,
+	minNumberOfChromosomes, maxNumberOfChromosomes, numberOfGenesPerChromosome int) string {
 
-	numberOfParentLines := max(1, solver.NumberOfConcurrentEvolvers)
-	for i := 0; i < numberOfParentLines; i++ {
-		go startEvolver(i + 1)
-	}
+	minNumberOfChromosomes = max(1, min(minNumberOfChromosomes, maxNumberOfChromosomes))
+	run := solver.newRun(preferShorter(createFitnessComparisonFunctions(solver.LowerFitnessesAreBetter, -1, false, solver.lengthPenalty(numberOfGenesPerChromosome))))
+	run.seed(getFitness, geneSet, numberOfGenesPerChromosome, minNumberOfChromosomes, maxNumberOfChromosomes)
 
-	doneCount := 0
-	for {
-		select {
-		case id := <-done:
-			doneCount++
-			if solver.PrintDiagnosticInfo {
-				fmt.Println("e", id, " finished")
-			}
-			if doneCount == numberOfParentLines {
-				goto end
-			}
-		}
-	}
+	return run.solve(getFitness, display, geneSet, numberOfGenesPerChromosome, func(e *evolver) {
+		e.getBest(minNumberOfChromosomes, maxNumberOfChromosomes)
+	})
+}
 
-end:
-	solver.printStrategyUsage()
+func (solver *Solver) GetBestUsingHillClimbing(getFitness func(string) int,
+	display func(string),
+	geneSet string,
+	maxNumberOfChromosomes, numberOfGenesPerChromosome int,
+	bestPossibleFitness int) string {
 
-	return bestEver.genes
+	return solver.GetBestBytesUsingHillClimbing(fitnessOfString(getFitness), display, geneSet,
+		maxNumberOfChromosomes, numberOfGenesPerChromosome, bestPossibleFitness)
 }
 
-func (solver *Solver) GetBestUsingHillClimbing(getFitness func(string) int,
+// GetBestBytesUsingHillClimbing is GetBestUsingHillClimbing for a fitness
+// function that reads the genes as bytes, as with GetBestBytes.
+func (solver *Solver) GetBestBytesUsingHillClimbing(getFitness func(genes []byte) int,
 	display func(string),
 	geneSet string,
 	maxNumberOfChromosomes, numberOfGenesPerChromosome int,
 	bestPossibleFitness int) string {
 
-	quit := make(chan bool)
-	solver.initialize(getFitness, bestPossibleFitness, true)
+	run := solver.newRun(solver.preferShorterIfAsked(createFitnessComparisonFunctions(solver.LowerFitnessesAreBetter, bestPossibleFitness, true, solver.lengthPenalty(numberOfGenesPerChromosome))))
+	minNumberOfChromosomes := solver.getMinNumberOfChromosomes(maxNumberOfChromosomes)
+	run.seed(getFitness, geneSet, numberOfGenesPerChromosome, minNumberOfChromosomes, maxNumberOfChromosomes)
+
+	isOptimal := func(fitness int) bool {
+		return fitness == bestPossibleFitness
+	}
+	return run.solve(getFitness, display, geneSet, numberOfGenesPerChromosome, func(e *evolver) {
+		e.getBestUsingHillClimbing(minNumberOfChromosomes, maxNumberOfChromosomes, isOptimal, solver.MaxRoundsWithoutGrowthImprovement)
+	})
+}
+
+// GetBestUsingOpenEndedHillClimbing is GetBestUsingHillClimbing for
+// problems without a known best possible fitness. Fitnesses are better
+// when higher, or lower if LowerFitnessesAreBetter, as with GetBest.
+// Sequences stop growing once growth has not led to a better one for
+// MaxRoundsWithoutGrowthImprovement rounds, which defaults to
+// MaxRoundsWithoutImprovement.
+func (solver *Solver) GetBestUsingOpenEndedHillClimbing(getFitness func(string) int,
+	display func(string),
+	geneSet string,
+	maxNumberOfChromosomes, numberOfGenesPerChromosome int) string {
+
+	return solver.GetBestBytesUsingOpenEndedHillClimbing(fitnessOfString(getFitness), display, geneSet,
+		maxNumberOfChromosomes, numberOfGenesPerChromosome)
+}
 
-	defer func() {
-		quit <- true
-		solver.initialParentGenes = ""
-	}()
+// GetBestBytesUsingOpenEndedHillClimbing is
+// GetBestUsingOpenEndedHillClimbing for a fitness function that reads the
+// genes as bytes, as with GetBestBytes.
+func (solver *Solver) GetBestBytesUsingOpenEndedHillClimbing(getFitness func(genes []byte) int,
+	display func(string),
+	geneSet string,
+	maxNumberOfChromosomes, numberOfGenesPerChromosome int) string {
 
-	bestEver := solver.initialParent
-	displayCaptureBest := make(chan *sequenceInfo)
+	run := solver.newRun(solver.preferShorterIfAsked(createFitnessComparisonFunctions(solver.LowerFitnessesAreBetter, -1, false, solver.lengthPenalty(numberOfGenesPerChromosome))))
+	minNumberOfChromosomes := solver.getMinNumberOfChromosomes(maxNumberOfChromosomes)
+	run.seed(getFitness, geneSet, numberOfGenesPerChromosome, minNumberOfChromosomes, maxNumberOfChromosomes)
 
-	if solver.MaxProcs > 1 {
-		runtime.GOMAXPROCS(min(solver.MaxProcs, runtime.NumCPU()))
+	maxRoundsWithoutGrowthImprovement := solver.MaxRoundsWithoutGrowthImprovement
+	if maxRoundsWithoutGrowthImprovement == 0 {
+		maxRoundsWithoutGrowthImprovement = run.maxRoundsWithoutImprovement
+	}
+	isOptimal := func(fitness int) bool {
+		return false
 	}
+	return run.solve(getFitness, display, geneSet, numberOfGenesPerChromosome, func(e *evolver) {
+		e.getBestUsingHillClimbing(minNumberOfChromosomes, maxNumberOfChromosomes, isOptimal, maxRoundsWithoutGrowthImprovement)
+	})
+}
 
-	go func() {
-		for {
-			select {
-			case <-quit:
-				quit <- true
-				return
-			case candidate := <-displayCaptureBest:
-				if !solver.childFitnessIsBetter(candidate, &bestEver) {
-					continue
-				}
-				if solver.PrintDiagnosticInfo {
-					fmt.Print("e ", candidate.evolverId, "\t", candidate.strategy.name)
-				}
-				display(candidate.genes)
+// fitnessOfString gives getFitness its own copy of the genes, which it is
+// free to keep.
+func fitnessOfString(getFitness func(string) int) func([]byte) int {
+	return func(genes []byte) int {
+		return getFitness(string(genes))
+	}
+}
 
-				solver.incrementStrategyUseCount(candidate, &bestEver)
+// With makes initialParentGenes the starting point of the next run.
+func (solver *Solver) With(initialParentGenes string) *Solver {
+	if len(initialParentGenes) == 0 {
+		return solver.WithSeeds(nil)
+	}
+	return solver.WithSeeds([]string{initialParentGenes})
+}
 
-				bestEver = *candidate
-			}
-		}
-	}()
-
-	done := make(chan int)
-	startEvolver := func(id int) {
-		for {
-			initialParent := bestEver
-
-			e := evolver{
-				maxSecondsToRunWithoutImprovement: solver.MaxSecondsToRunWithoutImprovement,
-				maxRoundsWithoutImprovement:       solver.MaxRoundsWithoutImprovement,
-				lowerFitnessesAreBetter:           solver.LowerFitnessesAreBetter,
-				childFitnessIsBetter:              solver.childFitnessIsBetter,
-				childFitnessIsSameOrBetter:        solver.childFitnessIsSameOrBetter,
-				geneSet:                           geneSet,
-				numberOfGenesPerChromosome:        numberOfGenesPerChromosome,
-				initialParent:                     initialParent,
-				display:                           displayCaptureBest,
-				getFitness:                        getFitness,
-				id:                                id,
-			}
+// WithSeeds adds seeds, such as the results of a quick heuristic, to the
+// initial pool of every evolver in the next run, which starts from the
+// best of them. Seeds that have genes outside the gene set, or a length
+// the run cannot use, are logged and left out.
+func (solver *Solver) WithSeeds(seeds []string) *Solver {
+	solver.lock.Lock()
+	defer solver.lock.Unlock()
+	solver.seeds = append([]string(nil), seeds...)
+	return solver
+}
 
-			e.getBestUsingHillClimbing(maxNumberOfChromosomes, bestPossibleFitness)
+// takeSeeds hands the seeds given to With or WithSeeds to a single run.
+func (solver *Solver) takeSeeds() []string {
+	solver.lock.Lock()
+	defer solver.lock.Unlock()
+	seeds := solver.seeds
+	solver.seeds = nil
+	return seeds
+}
 
-			if solver.NumberOfConcurrentEvolvers < 2 ||
-				initialParent.genes == bestEver.genes {
-				break
-			}
-			if solver.PrintDiagnosticInfo {
-				fmt.Println("e", id, " restarting")
-			}
-		}
-		done <- id
+// startRun lets Stop reach the run, and makes it the one Statistics and
+// Pools describe.
+func (solver *Solver) startRun(started *run) {
+	solver.lock.Lock()
+	defer solver.lock.Unlock()
+	if solver.runs == nil {
+		solver.runs = make(map[*run]bool)
 	}
+	solver.runs[started] = true
+	solver.stats.Store(started.stats)
+}
 
-	numberOfParentLines := max(1, solver.NumberOfConcurrentEvolvers)
-	for i := 0; i < numberOfParentLines; i++ {
-		go startEvolver(i + 1)
+func (solver *Solver) finishRun(finished *run) {
+	solver.lock.Lock()
+	defer solver.lock.Unlock()
+	delete(solver.runs, finished)
+}
+
+// createRandomNumberGenerators returns nil, meaning unseeded generators,
+// unless RandomSeed is set, in which case each evolver draws from its own
+// seeded sequence.
+func (solver *Solver) createRandomNumberGenerators(evolverId int) func() RandomSource {
+	if solver.RandomSeed == 0 {
+		return nil
 	}
+	return createSeededRandomNumberGenerators(solver.RandomSeed + int64(evolverId)<<32)
+}
 
-	doneCount := 0
-	for {
-		select {
-		case id := <-done:
-			doneCount++
-			if solver.PrintDiagnosticInfo {
-				fmt.Println("e", id, " finished")
-			}
-			if doneCount == numberOfParentLines {
-				goto end
-			}
-		}
+func (solver *Solver) createNicheSettings() *nicheSettings {
+	if solver.Replacement == ReplaceWorst {
+		return nil
 	}
+	return &nicheSettings{
+		policy:   solver.Replacement,
+		distance: distanceBetweenGenes(solver.Distance),
+		radius:   solver.NicheRadius,
+		capacity: solver.NicheCapacity,
+	}
+}
 
-end:
-	solver.printStrategyUsage()
+func (solver *Solver) getPoolSizeFunc() func(numberOfChromosomes, numberOfGenesPerChromosome, numberOfGenes int) int {
+	if solver.PoolSizeFunc != nil {
+		return solver.PoolSizeFunc
+	}
+	if solver.PoolSize > 0 {
+		poolSize := solver.PoolSize
+		return func(numberOfChromosomes, numberOfGenesPerChromosome, numberOfGenes int) int {
+			return poolSize
+		}
+	}
+	return nil
+}
 
-	return bestEver.genes
+// getMinNumberOfChromosomes returns the length hill climbing starts from
+// and never goes below.
+func (solver *Solver) getMinNumberOfChromosomes(maxNumberOfChromosomes int) int {
+	return max(1, min(solver.MinNumberOfChromosomes, maxNumberOfChromosomes))
 }
 
-func (solver *Solver) With(initialParentGenes string) *Solver {
-	solver.initialParentGenes = initialParentGenes
-	return solver
+// Diversity returns the mean distance between members of the evolvers'
+// pools at the end of the most recent run.
+func (solver *Solver) Diversity() float64 {
+	return solver.Statistics().Diversity
 }
 
-func (solver *Solver) createFitnessComparisonFunctions(bestPossibleFitness int, isHillClimbing bool) {
+func createFitnessComparisonFunctions(lowerFitnessesAreBetter bool, bestPossibleFitness int, isHillClimbing bool, lengthPenalty func(*sequenceInfo) int) (childFitnessIsBetter, childFitnessIsSameOrBetter func(child, other *sequenceInfo) bool) {
+	// sequences are ranked by their fitness after any length penalty,
+	// while validity and the optimum are judged on the fitness itself
+	rankOf := func(sequence *sequenceInfo) int {
+		return sequence.fitness
+	}
+	if lengthPenalty != nil {
+		rankOf = func(sequence *sequenceInfo) int {
+			return sequence.fitness + lengthPenalty(sequence)
+		}
+	}
+
 	if !isHillClimbing {
-		if solver.LowerFitnessesAreBetter {
-			solver.childFitnessIsBetter = func(child, other *sequenceInfo) bool {
-				return child.fitness < other.fitness
+		if lowerFitnessesAreBetter {
+			childFitnessIsBetter = func(child, other *sequenceInfo) bool {
+				return rankOf(child) < rankOf(other)
 			}
 
-			solver.childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
-				return child.fitness <= other.fitness
+			childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
+				return rankOf(child) <= rankOf(other)
 			}
 		} else {
-			solver.childFitnessIsBetter = func(child, other *sequenceInfo) bool {
-				return child.fitness > other.fitness
+			childFitnessIsBetter = func(child, other *sequenceInfo) bool {
+				return rankOf(child) > rankOf(other)
 			}
 
-			solver.childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
-				return child.fitness >= other.fitness
+			childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
+				return rankOf(child) >= rankOf(other)
 			}
 		}
 	} else {
@@ -264,22 +332,22 @@ func (solver *Solver) createFitnessComparisonFunctions(bestPossibleFitness int,
 			return false, false
 		}
 
-		if solver.LowerFitnessesAreBetter {
-			solver.childFitnessIsBetter = func(child, other *sequenceInfo) bool {
+		if lowerFitnessesAreBetter {
+			childFitnessIsBetter = func(child, other *sequenceInfo) bool {
 				eitherIsInvalid, toReturn := checkIfEitherIsInvalid(child.fitness, other.fitness)
 				if eitherIsInvalid {
 					return toReturn
 				}
 
-				childVsOptimalLower, childVsOptimalHigher := sort(child.fitness, bestPossibleFitness)
-				otherVsOptimalLower, otherVsOptimalHigher := sort(other.fitness, bestPossibleFitness)
+				childVsOptimalLower, childVsOptimalHigher := sort(rankOf(child), bestPossibleFitness)
+				otherVsOptimalLower, otherVsOptimalHigher := sort(rankOf(other), bestPossibleFitness)
 				if childVsOptimalHigher-childVsOptimalLower < otherVsOptimalHigher-otherVsOptimalLower {
 					return child.fitness >= bestPossibleFitness
 				}
 				return false
 			}
 
-			solver.childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
+			childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
 				eitherIsInvalid, toReturn := checkIfEitherIsInvalid(child.fitness, other.fitness)
 				if eitherIsInvalid {
 					return toReturn
@@ -290,29 +358,29 @@ func (solver *Solver) createFitnessComparisonFunctions(bestPossibleFitness int,
 					return len(child.genes) <= len(other.genes)
 				}
 
-				childVsOptimalLower, childVsOptimalHigher := sort(child.fitness, bestPossibleFitness)
-				otherVsOptimalLower, otherVsOptimalHigher := sort(other.fitness, bestPossibleFitness)
+				childVsOptimalLower, childVsOptimalHigher := sort(rankOf(child), bestPossibleFitness)
+				otherVsOptimalLower, otherVsOptimalHigher := sort(rankOf(other), bestPossibleFitness)
 				if childVsOptimalHigher-childVsOptimalLower <= otherVsOptimalHigher-otherVsOptimalLower {
 					return child.fitness >= bestPossibleFitness
 				}
 				return false
 			}
 		} else {
-			solver.childFitnessIsBetter = func(child, other *sequenceInfo) bool {
+			childFitnessIsBetter = func(child, other *sequenceInfo) bool {
 				eitherIsInvalid, toReturn := checkIfEitherIsInvalid(child.fitness, other.fitness)
 				if eitherIsInvalid {
 					return toReturn
 				}
 
-				childVsOptimalLower, childVsOptimalHigher := sort(child.fitness, bestPossibleFitness)
-				otherVsOptimalLower, otherVsOptimalHigher := sort(other.fitness, bestPossibleFitness)
+				childVsOptimalLower, childVsOptimalHigher := sort(rankOf(child), bestPossibleFitness)
+				otherVsOptimalLower, otherVsOptimalHigher := sort(rankOf(other), bestPossibleFitness)
 				if childVsOptimalHigher-childVsOptimalLower < otherVsOptimalHigher-otherVsOptimalLower {
 					return child.fitness <= bestPossibleFitness
 				}
 				return false
 			}
 
-			solver.childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
+			childFitnessIsSameOrBetter = func(child, other *sequenceInfo) bool {
 				eitherIsInvalid, toReturn := checkIfEitherIsInvalid(child.fitness, other.fitness)
 				if eitherIsInvalid {
 					return toReturn
@@ -323,8 +391,8 @@ func (solver *Solver) createFitnessComparisonFunctions(bestPossibleFitness int,
 					return len(child.genes) <= len(other.genes)
 				}
 
-				childVsOptimalLower, childVsOptimalHigher := sort(child.fitness, bestPossibleFitness)
-				otherVsOptimalLower, otherVsOptimalHigher := sort(other.fitness, bestPossibleFitness)
+				childVsOptimalLower, childVsOptimalHigher := sort(rankOf(child), bestPossibleFitness)
+				otherVsOptimalLower, otherVsOptimalHigher := sort(rankOf(other), bestPossibleFitness)
 				if childVsOptimalHigher-childVsOptimalLower <= otherVsOptimalHigher-otherVsOptimalLower {
 					return child.fitness <= bestPossibleFitness
 				}
@@ -332,74 +400,46 @@ func (solver *Solver) createFitnessComparisonFunctions(bestPossibleFitness int,
 			}
 		}
 	}
+	return childFitnessIsBetter, childFitnessIsSameOrBetter
 }
 
-func (solver *Solver) ensureMaxSecondsToRunIsValid() {
-	if solver.MaxSecondsToRunWithoutImprovement == 0 {
-		solver.MaxSecondsToRunWithoutImprovement = 20
-		fmt.Printf("\tSolver will run at most %v second(s) without improvement.\n", solver.MaxSecondsToRunWithoutImprovement)
-	}
-}
-
-func (solver *Solver) incrementStrategyUseCount(candidate, bestEver *sequenceInfo) {
-	if bestEver.genes == candidate.parent.genes {
-		solver.successParentIsBestParentCount++
+// preferShorter breaks ties in fitness in favor of the shorter sequence.
+func preferShorter(childFitnessIsBetter, childFitnessIsSameOrBetter func(child, other *sequenceInfo) bool) (isBetter, isSameOrBetter func(child, other *sequenceInfo) bool) {
+	isBetter = func(child, other *sequenceInfo) bool {
+		if child.fitness == other.fitness {
+			return len(child.genes) < len(other.genes)
+		}
+		return childFitnessIsBetter(child, other)
 	}
-	solver.numberOfImprovements++
-
-	strategyName := candidate.strategy.name
-	strategy, exists := solver.strategies[strategyName]
-	if !exists {
-		strategy = &strategyInfo{name: strategyName}
-		solver.strategies[strategyName] = strategy
+	isSameOrBetter = func(child, other *sequenceInfo) bool {
+		if child.fitness == other.fitness {
+			return len(child.genes) <= len(other.genes)
+		}
+		return childFitnessIsSameOrBetter(child, other)
 	}
-	strategy.successCount++
+	return isBetter, isSameOrBetter
 }
 
-func (solver *Solver) initialize(getFitness func(string) int, optimalFitness int, isHillClimbing bool) {
-	if solver.MaxRoundsWithoutImprovement == 0 {
-		solver.MaxRoundsWithoutImprovement = 2
-	}
-	solver.ensureMaxSecondsToRunIsValid()
-	solver.createFitnessComparisonFunctions(optimalFitness, isHillClimbing)
-
-	solver.strategies = make(map[string]*strategyInfo, 10)
-
-	initialParent := sequenceInfo{genes: solver.initialParentGenes}
-	if len(initialParent.genes) == 0 {
-		if solver.LowerFitnessesAreBetter {
-			initialParent.fitness = math.MaxInt32
-		} else {
-			initialParent.fitness = math.MinInt32
-		}
-	} else {
-		initialParent.fitness = getFitness(solver.initialParent.genes)
+// preferShorterIfAsked applies preferShorter when PreferShorter is set.
+func (solver *Solver) preferShorterIfAsked(childFitnessIsBetter, childFitnessIsSameOrBetter func(child, other *sequenceInfo) bool) (isBetter, isSameOrBetter func(child, other *sequenceInfo) bool) {
+	if !solver.PreferShorter {
+		return childFitnessIsBetter, childFitnessIsSameOrBetter
 	}
-	initialParent.parent = &solver.initialParent
-	solver.initialParent = initialParent
-
+	return preferShorter(childFitnessIsBetter, childFitnessIsSameOrBetter)
 }
 
-func (solver *Solver) printStrategyUsage() {
-	if !solver.PrintStrategyUsage {
-		return
+// lengthPenalty returns how much fitness a sequence loses for its
+// length, LengthPenalty per chromosome, or nil if there is no penalty.
+func (solver *Solver) lengthPenalty(numberOfGenesPerChromosome int) func(*sequenceInfo) int {
+	if solver.LengthPenalty == 0 {
+		return nil
 	}
-
-	var multiplier = 100
-	if solver.numberOfImprovements == 0 {
-		solver.numberOfImprovements = 1
-		multiplier = 1
+	penaltyPerChromosome := solver.LengthPenalty
+	if !solver.LowerFitnessesAreBetter {
+		penaltyPerChromosome = -penaltyPerChromosome
 	}
-	fmt.Println("\nsuccessful strategy usage:")
-	for _, strategy := range solver.strategies {
-		fmt.Println(
-			strategy.name, "\t",
-			strategy.successCount, "\t",
-			multiplier*strategy.successCount/solver.numberOfImprovements, "%")
+	return func(sequence *sequenceInfo) int {
+		numberOfChromosomes := len(sequence.genes) / max(1, numberOfGenesPerChromosome)
+		return int(math.Round(penaltyPerChromosome * float64(numberOfChromosomes)))
 	}
-	fmt.Println()
-
-	fmt.Println("\nNew champions were children of the reigning champion",
-		multiplier*solver.successParentIsBestParentCount/solver.numberOfImprovements,
-		"% of the time.")
 }
@@ -0,0 +1,393 @@
+package genetic
+
+import (
+	"bytes"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestSolver creates a seeded solver with a small pool that gives up
+// after 50ms without improvement.
+func newTestSolver() *Solver {
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = .05
+	solver.RandomSeed = 1
+	solver.PoolSize = 20
+	return solver
+}
+
+// stopWhenDisplayed returns a display that stops the solver once it shows
+// genes that are done, so a test expecting them does not race the solver's
+// timer. The solver is given ten seconds without improvement to get there.
+func stopWhenDisplayed(solver *Solver, done func(genes string) bool) func(string) {
+	solver.MaxSecondsToRunWithoutImprovement = 10
+	return func(genes string) {
+		if done(genes) {
+			solver.Stop()
+		}
+	}
+}
+
+func TestGetBestBytesKeepsWhatItDisplays(t *testing.T) {
+	solver := newTestSolver()
+	stop := stopWhenDisplayed(solver, func(genes string) bool { return countOnes(genes) == 30 })
+
+	var displayed []string
+	var fitnesses []int
+	display := func(genes string) {
+		displayed = append(displayed, genes)
+		fitnesses = append(fitnesses, countOnes(genes))
+		stop(genes)
+	}
+	countOnesInBytes := func(genes []byte) int {
+		return bytes.Count(genes, []byte("1"))
+	}
+
+	best := solver.GetBestBytes(countOnesInBytes, display, "01", 30, 1)
+
+	if countOnes(best) != 30 {
+		t.Errorf("expected all ones, got %s", best)
+	}
+	// children thrown away are recycled; nothing shown may change after
+	for i, genes := range displayed {
+		if countOnes(genes) != fitnesses[i] {
+			t.Errorf("expected %s to still have %d ones", genes, fitnesses[i])
+		}
+	}
+}
+
+func TestOneSolverRunsProblemsConcurrently(t *testing.T) {
+	solver := newTestSolver()
+	solver.MaxProcs = 2
+	procs := runtime.GOMAXPROCS(0)
+
+	// Stop ends both runs, so it waits until both are solved
+	var solved atomic.Int32
+	stop := stopWhenDisplayed(solver, func(string) bool { return solved.Add(1) == 2 })
+	countZeros := func(genes string) int { return strings.Count(genes, "0") }
+	zeros := make(chan string)
+	go func() {
+		zeros <- solver.GetBest(countZeros, func(genes string) {
+			if countZeros(genes) == 20 {
+				stop(genes)
+			}
+		}, "01", 20, 1)
+	}()
+	ones := solver.GetBest(countOnes, func(genes string) {
+		if countOnes(genes) == 30 {
+			stop(genes)
+		}
+	}, "01", 30, 1)
+
+	if countOnes(ones) != 30 {
+		t.Errorf("expected all ones, got %s", ones)
+	}
+	if best := <-zeros; countZeros(best) != 20 {
+		t.Errorf("expected all zeros, got %s", best)
+	}
+	if runtime.GOMAXPROCS(0) != procs {
+		t.Errorf("expected GOMAXPROCS to stay %d, got %d", procs, runtime.GOMAXPROCS(0))
+	}
+}
+
+func TestRunsDoNotShareStatistics(t *testing.T) {
+	solver := newTestSolver()
+
+	for run := 1; run <= 2; run++ {
+		var calls atomic.Int64
+		getFitness := func(genes string) int {
+			calls.Add(1)
+			return countOnes(genes)
+		}
+		solver.GetBest(getFitness, func(string) {}, "01", 30, 1)
+
+		if evaluations := solver.Statistics().Evaluations; evaluations != calls.Load() {
+			t.Errorf("run %d: expected the %d evaluations it made, got %d", run, calls.Load(), evaluations)
+		}
+	}
+}
+
+func TestSeedsStartTheRunAndUnsuitableOnesAreLeftOut(t *testing.T) {
+	solver := newTestSolver()
+
+	// twos would win if they were allowed in
+	getFitness := func(genes string) int {
+		return strings.Count(genes, "1") + 10*strings.Count(genes, "2")
+	}
+	var first string
+	display := func(genes string) {
+		if first == "" {
+			first = genes
+		}
+	}
+	allOnes := strings.Repeat("1", 30)
+	best := solver.WithSeeds([]string{
+		strings.Repeat("2", 30),
+		strings.Repeat("0", 29) + "1",
+		allOnes,
+		strings.Repeat("1", 29),
+	}).GetBest(getFitness, display, "01", 30, 1)
+
+	if best != allOnes {
+		t.Errorf("expected the best valid seed, got %s", best)
+	}
+	if first != "" {
+		t.Errorf("expected nothing to beat the best seed, but %s was displayed", first)
+	}
+}
+
+func TestWithScoresTheGenesItIsGiven(t *testing.T) {
+	solver := newTestSolver()
+
+	displayed := 0
+	allOnes := strings.Repeat("1", 30)
+	best := solver.With(allOnes).GetBest(countOnes, func(string) { displayed++ }, "01", 30, 1)
+
+	if best != allOnes {
+		t.Errorf("expected the initial parent to be kept, got %s", best)
+	}
+	if displayed != 0 {
+		t.Errorf("expected nothing to beat the initial parent, but %d sequences were displayed", displayed)
+	}
+}
+
+func TestHillClimbingCreditsTheGrowthPolicy(t *testing.T) {
+	solver := newTestSolver()
+	solver.MaxRoundsWithoutImprovement = 5
+	solver.Growth = InsertChromosome
+
+	countAs := func(genes string) int { return strings.Count(genes, "a") }
+	best := solver.GetBestUsingHillClimbing(countAs, func(string) {}, "ab", 8, 1, 8)
+
+	if best != "aaaaaaaa" {
+		t.Errorf("expected to grow to all a's, got %s", best)
+	}
+	improvements := solver.Statistics().Improvements
+	if improvements["insert"] == 0 {
+		t.Errorf("expected growth to be credited to insert, got %v", improvements)
+	}
+	if improvements["climb"] != 0 || improvements["add"] != 0 {
+		t.Errorf("expected nothing to be appended, got %v", improvements)
+	}
+}
+
+func TestGrowthPoliciesCombineByName(t *testing.T) {
+	var growth GrowthPolicy
+	if err := growth.UnmarshalText([]byte("insert, transplant")); err != nil {
+		t.Fatal(err)
+	}
+	if growth != InsertChromosome|TransplantChromosome {
+		t.Errorf("expected insert and transplant, got %v", growth)
+	}
+	if growth.String() != "insert,transplant" {
+		t.Errorf("expected insert,transplant, got %s", growth)
+	}
+	if err := growth.UnmarshalText([]byte("prepend")); err == nil {
+		t.Error("expected an unknown policy to be rejected")
+	}
+}
+
+func TestOpenEndedHillClimbingStopsGrowingWhenLengthStopsHelping(t *testing.T) {
+	solver := newTestSolver()
+	solver.MaxRoundsWithoutImprovement = 3
+
+	var lock sync.Mutex
+	var longest int
+	// a's help until there are five genes, after which length costs
+	getFitness := func(genes string) int {
+		lock.Lock()
+		longest = max(longest, len(genes))
+		lock.Unlock()
+		return strings.Count(genes, "a") - 2*max(0, len(genes)-5)
+	}
+	best := solver.GetBestUsingOpenEndedHillClimbing(getFitness, func(string) {}, "ab", 20, 1)
+
+	if best != "aaaaa" {
+		t.Errorf("expected aaaaa, got %s", best)
+	}
+	if longest >= 20 {
+		t.Errorf("expected growth to stop well short of the maximum, reached %d genes", longest)
+	}
+}
+
+func TestVariableLengthRunsPreferTheShortestOfEquallyFitSequences(t *testing.T) {
+	solver := newTestSolver()
+
+	var lock sync.Mutex
+	lengths := make(map[int]bool)
+	// any five ones are as good as more
+	getFitness := func(genes string) int {
+		lock.Lock()
+		lengths[len(genes)] = true
+		lock.Unlock()
+		return min(5, strings.Count(genes, "1"))
+	}
+	// the longer seeds come first, so only their length can put them last
+	best := solver.WithSeeds([]string{"0111110", "111111", "11111"}).
+		GetBestOfVariableLength(getFitness, func(string) {}, "01", 2, 12, 1)
+
+	if best != "11111" {
+		t.Errorf("expected the shortest sequence of five ones, got %s", best)
+	}
+	if len(lengths) < 3 {
+		t.Errorf("expected sequences of many lengths, got %v", lengths)
+	}
+	for length := range lengths {
+		if length < 2 || length > 12 {
+			t.Errorf("expected lengths from 2 to 12, got %d", length)
+		}
+	}
+}
+
+func TestHillClimbingStartsFromTheMinimumLength(t *testing.T) {
+	solver := newTestSolver()
+	solver.MaxRoundsWithoutImprovement = 5
+	solver.MinNumberOfChromosomes = 4
+
+	var lock sync.Mutex
+	shortest := math.MaxInt32
+	getFitness := func(genes string) int {
+		lock.Lock()
+		shortest = min(shortest, len(genes))
+		lock.Unlock()
+		return strings.Count(genes, "1")
+	}
+	best := solver.GetBestUsingHillClimbing(getFitness, func(string) {}, "01", 8, 1, 8)
+
+	if best != "11111111" {
+		t.Errorf("expected all ones, got %s", best)
+	}
+	if shortest != 4 {
+		t.Errorf("expected nothing shorter than 4 genes, got %d", shortest)
+	}
+}
+
+func TestLengthPenaltyTradesFitnessForLength(t *testing.T) {
+	solver := newTestSolver()
+	solver.LengthPenalty = 1.5
+
+	// every one is worth less than the chromosome holding it costs
+	display := stopWhenDisplayed(solver, func(genes string) bool { return genes == "11" })
+	best := solver.GetBestOfVariableLength(countOnes, display, "01", 2, 12, 1)
+
+	if best != "11" {
+		t.Errorf("expected the shortest sequence of ones, got %s", best)
+	}
+	lengths := solver.Statistics().Evolvers[0].Lengths
+	if len(lengths) == 0 {
+		t.Fatal("expected the lengths in the pool to be counted")
+	}
+	for length := range lengths {
+		if length < 2 || length > 12 {
+			t.Errorf("expected lengths from 2 to 12, got %d", length)
+		}
+	}
+}
+
+func TestPreferShorterBreaksTiesWhileHillClimbing(t *testing.T) {
+	solver := newTestSolver()
+	solver.MaxRoundsWithoutImprovement = 3
+	solver.PreferShorter = true
+
+	// three a's are as good as more
+	getFitness := func(genes string) int { return min(3, strings.Count(genes, "a")) }
+	best := solver.GetBestUsingOpenEndedHillClimbing(getFitness, func(string) {}, "ab", 10, 1)
+
+	if best != "aaa" {
+		t.Errorf("expected the shortest sequence of three a's, got %s", best)
+	}
+}
+
+func TestLengthPenaltyLeavesTheOptimumAndValidityOfHillClimbingAlone(t *testing.T) {
+	solver := newTestSolver()
+	solver.MaxRoundsWithoutImprovement = 50
+	solver.LengthPenalty = 1
+
+	countAs := func(genes string) int { return 10 * strings.Count(genes, "a") }
+	best := solver.GetBestUsingHillClimbing(countAs, func(string) {}, "ab", 3, 1, 30)
+
+	if best != "aaa" {
+		t.Errorf("expected aaa, got %s", best)
+	}
+	// 50 rounds without improvement would take 2.5s
+	if elapsed := solver.Statistics().Elapsed; elapsed >= time.Second {
+		t.Errorf("expected the run to stop at the optimum, took %v", elapsed)
+	}
+
+	isBetter, _ := createFitnessComparisonFunctions(false, 30, true, solver.lengthPenalty(1))
+	valid := &sequenceInfo{genes: []byte("bbb"), fitness: 0}
+	invalid := &sequenceInfo{genes: []byte("b"), fitness: -1}
+	if !isBetter(valid, invalid) || isBetter(invalid, valid) {
+		t.Error("expected a penalty below 0 to leave a valid sequence valid")
+	}
+
+	solver.LowerFitnessesAreBetter = true
+	isBetter, _ = createFitnessComparisonFunctions(true, 0, true, solver.lengthPenalty(1))
+	valid = &sequenceInfo{genes: []byte("bbb"), fitness: 5}
+	if !isBetter(valid, invalid) || isBetter(invalid, valid) {
+		t.Error("expected a penalty above 0 to leave an invalid sequence invalid")
+	}
+}
+
+type evolverImprovements struct {
+	lock sync.Mutex
+	// the fitness each evolver last reported
+	latest map[int]int
+}
+
+func (improvements *evolverImprovements) Observe(event Event) {
+	if event.Kind != EvolverImprovementEvent {
+		return
+	}
+	improvements.lock.Lock()
+	defer improvements.lock.Unlock()
+	improvements.latest[event.Evolver] = event.Fitness
+}
+
+func TestEvolversReportTheirOwnImprovements(t *testing.T) {
+	improvements := &evolverImprovements{latest: make(map[int]int)}
+	solver := newTestSolver()
+	solver.NumberOfConcurrentEvolvers = 3
+	solver.Observers = []Observer{improvements}
+
+	solver.GetBest(countOnes, func(string) {}, "01", 50, 1)
+
+	if len(improvements.latest) == 0 {
+		t.Fatal("expected evolvers to report improvements")
+	}
+	bestFitnesses := make(map[int]int)
+	for _, evolver := range solver.Statistics().Evolvers {
+		bestFitnesses[evolver.Id] = evolver.BestFitness
+	}
+	for id, fitness := range improvements.latest {
+		if id < 1 || id > 3 {
+			t.Errorf("expected evolvers 1 to 3, got %d", id)
+		}
+		if bestFitnesses[id] != fitness {
+			t.Errorf("evolver %d: expected its last report, %d, to be its best, got %d", id, fitness, bestFitnesses[id])
+		}
+	}
+}
+
+func TestStatisticsHoldTheFitnessOfTheGenesDisplayed(t *testing.T) {
+	solver := newTestSolver()
+	solver.NumberOfConcurrentEvolvers = 2
+
+	var displayed string
+	display := func(genes string) {
+		if fitness := solver.Statistics().BestFitness; fitness != countOnes(genes) {
+			t.Errorf("expected fitness %d for %s, got %d", countOnes(genes), genes, fitness)
+		}
+		displayed = genes
+	}
+	best := solver.GetBest(countOnes, display, "01", 30, 1)
+
+	if best != displayed || solver.Statistics().BestFitness != countOnes(best) {
+		t.Errorf("expected the fitness of %s, got %d", best, solver.Statistics().BestFitness)
+	}
+}
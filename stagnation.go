@@ -0,0 +1,143 @@
+package genetic
+
+import (
+	"time"
+)
+
+// StagnationResponse decides what an evolver does to its pool when it
+// stops improving.
+type StagnationResponse int
+
+const (
+	// NoStagnationResponse keeps evolving until MaxSecondsToRunWithoutImprovement.
+	NoStagnationResponse StagnationResponse = iota
+	// ReseedPool replaces ReseedPercent percent of the non-elite pool
+	// members with random sequences.
+	ReseedPool
+	// Hypermutate replaces every non-elite pool member with a heavily
+	// mutated copy of itself.
+	Hypermutate
+	// RestartWithElite discards everything but the elite and repopulates
+	// the pool with random sequences.
+	RestartWithElite
+)
+
+var stagnationResponseNames = []string{"none", "reseed", "hypermutate", "restart-with-elite"}
+
+func (response StagnationResponse) String() string {
+	return enumName(stagnationResponseNames, int(response))
+}
+
+func (response StagnationResponse) MarshalText() ([]byte, error) {
+	return []byte(response.String()), nil
+}
+
+func (response *StagnationResponse) UnmarshalText(text []byte) error {
+	value, err := parseEnumName(stagnationResponseNames, "StagnationResponse", string(text))
+	*response = StagnationResponse(value)
+	return err
+}
+
+type stagnationSettings struct {
+	response      StagnationResponse
+	seconds       float64
+	diversity     float64
+	eliteCount    int
+	reseedPercent int
+}
+
+func (stagnation *stagnationSettings) isEnabled() bool {
+	return stagnation.response != NoStagnationResponse &&
+		(stagnation.seconds > 0 || stagnation.diversity > 0)
+}
+
+func (evolver *evolver) isStagnant(sinceLastResponse time.Duration) bool {
+	stagnation := evolver.stagnation
+	if stagnation.seconds > 0 && sinceLastResponse.Seconds() >= stagnation.seconds {
+		return true
+	}
+	return stagnation.diversity > 0 &&
+		sinceLastResponse >= 100*time.Millisecond &&
+		evolver.pool.diversity(evolver.distance) < stagnation.diversity
+}
+
+// nextStagnationCheck returns when isStagnant may next be true, counting
+// from since. Diversity can only be found by measuring it, so once it is
+// eligible it is checked every 100ms.
+func (evolver *evolver) nextStagnationCheck(now, since time.Time) time.Time {
+	stagnation := evolver.stagnation
+	var due time.Time
+	if stagnation.seconds > 0 {
+		due = since.Add(time.Duration(stagnation.seconds * float64(time.Second)))
+	}
+	if stagnation.diversity > 0 {
+		check := since.Add(100 * time.Millisecond)
+		if check.Before(now) {
+			check = now.Add(100 * time.Millisecond)
+		}
+		if due.IsZero() || check.Before(due) {
+			due = check
+		}
+	}
+	return due
+}
+
+func (evolver *evolver) respondToStagnation(numberOfChromosomes int) {
+	eliteCount := max(1, evolver.stagnation.eliteCount)
+	items := evolver.pool.snapshot()
+	if len(items) <= eliteCount {
+		return
+	}
+
+	var replacements []*sequenceInfo
+	switch evolver.stagnation.response {
+	case ReseedPool:
+		percent := evolver.stagnation.reseedPercent
+		if percent <= 0 || percent > 100 {
+			percent = 50
+		}
+		numberToReplace := max(1, (len(items)-eliteCount)*percent/100)
+		replacements = evolver.createRandomSequences(numberToReplace, numberOfChromosomes)
+		evolver.pool.truncateAndAddAllTo(len(items)-numberToReplace, replacements)
+	case Hypermutate:
+		strategy := strategyInfo{name: "hyper     "}
+		for _, item := range items[eliteCount:] {
+			childGenes := evolver.hypermutate(item.genes)
+			child := sequenceInfo{genes: childGenes, strategy: strategy, parent: item}
+			evolver.evaluate(&child)
+			replacements = append(replacements, &child)
+		}
+		evolver.pool.truncateAndAddAllTo(eliteCount, replacements)
+	case RestartWithElite:
+		replacements = evolver.createRandomSequences(2*evolver.pool.cap(), numberOfChromosomes)
+		evolver.pool.truncateAndAddAllTo(eliteCount, replacements)
+	}
+	evolver.stats.recordStagnationResponse()
+	evolver.emit(Event{Kind: StagnationResponseEvent, Evolver: evolver.id, PoolSize: evolver.pool.len()})
+}
+
+func (evolver *evolver) createRandomSequences(count, numberOfChromosomes int) []*sequenceInfo {
+	strategy := strategyInfo{name: "reseed    "}
+	sequences := make([]*sequenceInfo, 0, count)
+	for i := 0; i < count; i++ {
+		genes := evolver.genes.parent(evolver.randomNumberOfChromosomes(numberOfChromosomes))
+		sequence := sequenceInfo{genes: genes, strategy: strategy}
+		sequence.parent = &sequence
+		evolver.evaluate(&sequence)
+		sequences = append(sequences, &sequence)
+	}
+	return sequences
+}
+
+// hypermutate replaces roughly a third of the genes.
+func (evolver *evolver) hypermutate(parentGenes []byte) []byte {
+	childGenes := make([]byte, len(parentGenes))
+	for i := range parentGenes {
+		if evolver.random.Intn(3) != 0 {
+			childGenes[i] = parentGenes[i]
+			continue
+		}
+		childGenes[i] = evolver.genes.gene()
+	}
+	return childGenes
+}
@@ -0,0 +1,142 @@
+package genetic
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newStagnantEvolver creates an evolver whose pool holds ten sequences of
+// a's and b's, best first, with fitness the number of a's. Items the
+// evolver adds to the pool wait in addNewItem until settle offers them.
+func newStagnantEvolver(stagnation stagnationSettings) *evolver {
+	e := &evolver{
+		geneSet:                    "ab",
+		numberOfGenesPerChromosome: 1,
+		getFitness:                 func(genes []byte) int { return bytes.Count(genes, []byte("a")) },
+		stagnation:                 stagnation,
+		distance:                   hammingDistance[[]byte],
+		stats:                      newRunStatistics(),
+		emit:                       func(Event) {},
+		newRandom:                  createSeededRandomNumberGenerators(1),
+	}
+	e.initialize()
+	isSameOrBetter := func(child, other *sequenceInfo) bool { return child.fitness >= other.fitness }
+	e.pool = makePool(10, isSameOrBetter, nil, e.random)
+	e.pool.lifetime = e.lifetime
+	e.pool.addNewItem = make(chan *sequenceInfo, 100)
+	for i := 0; i < 10; i++ {
+		genes := strings.Repeat("a", 10-i) + strings.Repeat("b", i)
+		e.pool.offer(&sequenceInfo{genes: []byte(genes), fitness: 10 - i})
+	}
+	return e
+}
+
+func (e *evolver) settle() {
+	for len(e.pool.addNewItem) > 0 {
+		e.pool.offer(<-e.pool.addNewItem)
+	}
+}
+
+func TestStagnationResponsesKeepTheElite(t *testing.T) {
+	for _, response := range []StagnationResponse{ReseedPool, Hypermutate, RestartWithElite} {
+		t.Run(response.String(), func(t *testing.T) {
+			e := newStagnantEvolver(stagnationSettings{response: response, eliteCount: 2})
+			original := e.pool.snapshot()
+
+			e.respondToStagnation(10)
+			e.settle()
+
+			for _, elite := range original[:2] {
+				if !e.pool.contains(elite) {
+					t.Errorf("expected %s to be kept", elite.genes)
+				}
+			}
+			if responses := e.stats.snapshot().StagnationResponses; responses != 1 {
+				t.Errorf("expected 1 stagnation response, got %d", responses)
+			}
+			if response == ReseedPool {
+				return
+			}
+			for _, item := range e.pool.snapshot() {
+				if item != original[0] && item != original[1] && item.strategy.name == "" {
+					t.Errorf("expected %s to be replaced", item.genes)
+				}
+			}
+		})
+	}
+}
+
+func TestReseedReplacesReseedPercentOfThePool(t *testing.T) {
+	e := newStagnantEvolver(stagnationSettings{response: ReseedPool, eliteCount: 2, reseedPercent: 50})
+	original := e.pool.snapshot()
+
+	e.respondToStagnation(10)
+	e.settle()
+
+	// half of the 8 non-elite items make way for random sequences
+	for i, item := range original {
+		if kept := e.pool.contains(item); kept != (i < 6) {
+			t.Errorf("expected %s kept to be %v", item.genes, i < 6)
+		}
+	}
+	reseeded := 0
+	for _, item := range e.pool.snapshot() {
+		if item.strategy.name == "reseed    " {
+			reseeded++
+		}
+	}
+	if reseeded != 4 {
+		t.Errorf("expected 4 random sequences, got %d", reseeded)
+	}
+}
+
+func TestStagnationIsTriggeredByTimeOrLowDiversity(t *testing.T) {
+	e := newStagnantEvolver(stagnationSettings{response: ReseedPool, seconds: 1})
+	if e.isStagnant(500 * time.Millisecond) {
+		t.Error("expected no response before StagnationSeconds")
+	}
+	if !e.isStagnant(time.Second) {
+		t.Error("expected a response after StagnationSeconds")
+	}
+
+	// the pool's mean distance is well below 100 and above 1
+	e = newStagnantEvolver(stagnationSettings{response: ReseedPool, diversity: 100})
+	if e.isStagnant(50 * time.Millisecond) {
+		t.Error("expected diversity to be left unmeasured for 100ms")
+	}
+	if !e.isStagnant(150 * time.Millisecond) {
+		t.Error("expected a response when diversity is below StagnationDiversity")
+	}
+	e = newStagnantEvolver(stagnationSettings{response: ReseedPool, diversity: 1})
+	if e.isStagnant(time.Hour) {
+		t.Error("expected no response while diversity is above StagnationDiversity")
+	}
+}
+
+func TestStagnantRunsRespond(t *testing.T) {
+	for _, settings := range []struct {
+		name               string
+		seconds, diversity float64
+	}{
+		{"no improvement", .01, 0},
+		{"low diversity", 0, 1000},
+	} {
+		t.Run(settings.name, func(t *testing.T) {
+			solver := new(Solver)
+			solver.MaxSecondsToRunWithoutImprovement = .3
+			solver.RandomSeed = 1
+			solver.StagnationResponse = Hypermutate
+			solver.StagnationSeconds = settings.seconds
+			solver.StagnationDiversity = settings.diversity
+
+			// nothing beats any other sequence
+			solver.GetBest(func(string) int { return 0 }, func(string) {}, "ab", 10, 1)
+
+			if responses := solver.Statistics().StagnationResponses; responses == 0 {
+				t.Error("expected the stagnant run to respond")
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package genetic
+
+import (
+	s "sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Statistics is a snapshot of the current, or most recent, run.
+type Statistics struct {
+	Elapsed             time.Duration
+	BestFitness         int // of the genes displayed last, 0 until then
+	Evaluations         int64
+	CacheHits           int64
+	EvolverRestarts     int
+	StagnationResponses int
+	Improvements        map[string]int
+	Evolvers            []EvolverStatistics
+	Diversity           float64
+}
+
+// EvolverStatistics describes a single evolver.
+type EvolverStatistics struct {
+	Id               int
+	BestFitness      int
+	PoolSize         int
+	Diversity        float64
+	SinceImprovement time.Duration
+	// Lengths counts the members of the pool by number of chromosomes
+	// when the evolver last finished.
+	Lengths map[int]int
+}
+
+// Candidate is a member of an evolver's pool.
+type Candidate struct {
+	Evolver  int
+	Genes    string
+	Fitness  int
+	Strategy string
+}
+
+// EvaluationsPerSecond returns the mean fitness evaluation rate.
+func (statistics Statistics) EvaluationsPerSecond() float64 {
+	if statistics.Elapsed <= 0 {
+		return 0
+	}
+	return float64(statistics.Evaluations) / statistics.Elapsed.Seconds()
+}
+
+// CacheHitRate returns the fraction of candidates whose evaluation was
+// skipped because an identical sequence was already in the pool.
+func (statistics Statistics) CacheHitRate() float64 {
+	lookups := statistics.Evaluations + statistics.CacheHits
+	if lookups == 0 {
+		return 0
+	}
+	return float64(statistics.CacheHits) / float64(lookups)
+}
+
+type runStatistics struct {
+	start       time.Time
+	evaluations atomic.Int64
+	cacheHits   atomic.Int64
+
+	lock                sync.Mutex
+	finished            time.Time
+	bestFitness         int
+	restarts            int
+	stagnationResponses int
+	improvements        map[string]int
+	evolvers            map[int]*evolverStatistics
+}
+
+type evolverStatistics struct {
+	bestFitness     int
+	pool            *pool
+	diversity       float64
+	lengths         map[int]int
+	lastImprovement time.Time
+}
+
+func newRunStatistics() *runStatistics {
+	return &runStatistics{
+		start:        time.Now(),
+		improvements: make(map[string]int),
+		evolvers:     make(map[int]*evolverStatistics),
+	}
+}
+
+// Statistics returns a snapshot of the most recently started run, which
+// may still be in progress.
+func (solver *Solver) Statistics() Statistics {
+	return solver.stats.Load().snapshot()
+}
+
+func (stats *runStatistics) snapshot() Statistics {
+	if stats == nil {
+		return Statistics{Improvements: map[string]int{}}
+	}
+
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+
+	end := stats.finished
+	if end.IsZero() {
+		end = time.Now()
+	}
+	statistics := Statistics{
+		Elapsed:             end.Sub(stats.start),
+		BestFitness:         stats.bestFitness,
+		Evaluations:         stats.evaluations.Load(),
+		CacheHits:           stats.cacheHits.Load(),
+		EvolverRestarts:     stats.restarts,
+		StagnationResponses: stats.stagnationResponses,
+		Improvements:        make(map[string]int, len(stats.improvements)),
+	}
+	for name, count := range stats.improvements {
+		statistics.Improvements[name] = count
+	}
+
+	diversities := make([]float64, 0, len(stats.evolvers))
+	for id, evolver := range stats.evolvers {
+		evolverStatistics := EvolverStatistics{
+			Id:               id,
+			BestFitness:      evolver.bestFitness,
+			Diversity:        evolver.diversity,
+			SinceImprovement: end.Sub(evolver.lastImprovement),
+			Lengths:          make(map[int]int, len(evolver.lengths)),
+		}
+		for length, count := range evolver.lengths {
+			evolverStatistics.Lengths[length] = count
+		}
+		if evolver.pool != nil {
+			evolverStatistics.PoolSize = evolver.pool.len()
+		}
+		statistics.Evolvers = append(statistics.Evolvers, evolverStatistics)
+		diversities = append(diversities, evolver.diversity)
+	}
+	s.Slice(statistics.Evolvers, func(i, j int) bool { return statistics.Evolvers[i].Id < statistics.Evolvers[j].Id })
+	statistics.Diversity = average(diversities)
+
+	return statistics
+}
+
+// Pools returns a copy of each evolver's pool in the most recently started
+// run, ordered by evolver and then best first, for inspecting a run in
+// progress.
+func (solver *Solver) Pools() []Candidate {
+	stats := solver.stats.Load()
+	if stats == nil {
+		return nil
+	}
+
+	stats.lock.Lock()
+	ids := make([]int, 0, len(stats.evolvers))
+	pools := make(map[int]*pool, len(stats.evolvers))
+	for id, evolver := range stats.evolvers {
+		if evolver.pool != nil {
+			ids = append(ids, id)
+			pools[id] = evolver.pool
+		}
+	}
+	stats.lock.Unlock()
+	s.Ints(ids)
+
+	var candidates []Candidate
+	for _, id := range ids {
+		for _, item := range pools[id].snapshot() {
+			candidates = append(candidates, Candidate{
+				Evolver:  id,
+				Genes:    string(item.genes),
+				Fitness:  item.fitness,
+				Strategy: strings.TrimSpace(item.strategy.name),
+			})
+		}
+	}
+	return candidates
+}
+
+func (stats *runStatistics) countEvaluations(getFitness func([]byte) int) func([]byte) int {
+	return func(genes []byte) int {
+		stats.evaluations.Add(1)
+		return getFitness(genes)
+	}
+}
+
+func (stats *runStatistics) evolver(id int) *evolverStatistics {
+	evolver, exists := stats.evolvers[id]
+	if !exists {
+		evolver = &evolverStatistics{}
+		stats.evolvers[id] = evolver
+	}
+	return evolver
+}
+
+func (stats *runStatistics) diversityOf(id int) float64 {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	return stats.evolver(id).diversity
+}
+
+func (stats *runStatistics) lengthsOf(id int) map[int]int {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	return stats.evolver(id).lengths
+}
+
+func (stats *runStatistics) finish() {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.finished = time.Now()
+}
+
+func (stats *runStatistics) recordBest(best *sequenceInfo) {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.bestFitness = best.fitness
+}
+
+func (stats *runStatistics) recordCacheHit() {
+	stats.cacheHits.Add(1)
+}
+
+func (stats *runStatistics) recordDiversity(id int, diversity float64) {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.evolver(id).diversity = diversity
+}
+
+func (stats *runStatistics) recordEvolverBest(id int, best *sequenceInfo) {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	evolver := stats.evolver(id)
+	evolver.bestFitness = best.fitness
+	evolver.lastImprovement = time.Now()
+}
+
+func (stats *runStatistics) recordImprovement(candidate *sequenceInfo) {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.improvements[strings.TrimSpace(candidate.strategy.name)]++
+}
+
+func (stats *runStatistics) recordLengths(id int, lengths map[int]int) {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.evolver(id).lengths = lengths
+}
+
+func (stats *runStatistics) recordPool(id int, p *pool) {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	evolver := stats.evolver(id)
+	evolver.pool = p
+	evolver.lastImprovement = time.Now()
+}
+
+func (stats *runStatistics) recordRestart() {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.restarts++
+}
+
+func (stats *runStatistics) recordStagnationResponse() {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.stagnationResponses++
+}
@@ -0,0 +1,176 @@
+package genetic_test
+
+import (
+	genetic "github.com/handcraftsman/GeneticGo"
+	"github.com/handcraftsman/GeneticGo/genetictest"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func operator(t *testing.T, name string, geneSet string, numberOfGenesPerChromosome int) genetic.Operator {
+	for _, operator := range genetic.Operators(geneSet, numberOfGenesPerChromosome) {
+		if operator.Name == name {
+			return operator
+		}
+	}
+	t.Fatalf("no operator named %s", name)
+	return genetic.Operator{}
+}
+
+func randomGenome(random *rand.Rand, geneSet string, length int) string {
+	genes := make([]byte, length)
+	for i := range genes {
+		genes[i] = geneSet[random.Intn(len(geneSet))]
+	}
+	return string(genes)
+}
+
+func TestOperatorsKeepLengthAndAlignment(t *testing.T) {
+	const geneSet = "abcdefghij"
+
+	type check func(t *testing.T, child string, n int, parents []string)
+	sameLength := func(t *testing.T, child string, n int, parents []string) {
+		genetictest.AssertChromosomes(t, child, n, len(parents[0])/n)
+	}
+	wholeChromosomes := func(t *testing.T, child string, n int, parents []string) {
+		genetictest.AssertWholeChromosomes(t, child, n, parents...)
+	}
+	withinOneChromosome := func(t *testing.T, child string, n int, parents []string) {
+		genetictest.AssertWithinOneChromosome(t, parents[0], child, n)
+	}
+
+	oneLonger := func(t *testing.T, child string, n int, parents []string) {
+		genetictest.AssertChromosomes(t, child, n, len(parents[0])/n+1)
+	}
+
+	tests := map[string][]check{
+		"add":       {wholeChromosomes, oneLonger},
+		"crossover": {sameLength, wholeChromosomes},
+		"duplicate": {wholeChromosomes, oneLonger},
+		"flutter":   {withinOneChromosome},
+		"insert":    {oneLonger},
+		"mutate":    {sameLength},
+		"random":    {sameLength},
+		"remove": {wholeChromosomes, func(t *testing.T, child string, n int, parents []string) {
+			genetictest.AssertChromosomes(t, child, n, len(parents[0])/n-1)
+		}},
+		"replace":    {withinOneChromosome},
+		"reverse":    {sameLength, wholeChromosomes},
+		"shift":      {sameLength, wholeChromosomes},
+		"swap":       {sameLength},
+		"transplant": {wholeChromosomes, oneLonger},
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3} {
+		operators := genetic.Operators(geneSet, n)
+		if len(operators) != len(tests) {
+			t.Fatalf("expected %d operators, got %d", len(tests), len(operators))
+		}
+		for _, operator := range operators {
+			checks, ok := tests[operator.Name]
+			if !ok {
+				t.Fatalf("no checks for %s", operator.Name)
+			}
+			for i := 0; i < 500; i++ {
+				parentA := randomGenome(random, geneSet, n*(1+random.Intn(6)))
+				parentB := randomGenome(random, geneSet, n*(1+random.Intn(6)))
+				child, parents := genetictest.Apply(operator, random, parentA, parentB)
+				if child == "" {
+					continue
+				}
+				genetictest.AssertAligned(t, child, n)
+				for _, check := range checks {
+					check(t, child, n, parents)
+				}
+				if t.Failed() {
+					t.Fatalf("%s with %d genes per chromosome made %q from %q", operator.Name, n, child, parents)
+				}
+			}
+		}
+	}
+}
+
+func TestOperatorsDoNotApplyToASingleChromosome(t *testing.T) {
+	for _, name := range []string{"crossover", "remove", "replace", "reverse", "shift"} {
+		child, _ := genetictest.Apply(operator(t, name, "abc", 2), genetictest.NewScript(t), "ab")
+		if child != "" {
+			t.Errorf("expected %s not to apply, got %q", name, child)
+		}
+	}
+}
+
+func TestFlutterPrefersLaterChromosomes(t *testing.T) {
+	flutter := operator(t, "flutter", "abcdefghi", 3)
+	// pass over the last two chromosomes, take the second and move its
+	// first gene up one in the gene set
+	random := genetictest.NewScript(t, 1, 1, 0, 0, 0, 3)
+
+	child, _ := genetictest.Apply(flutter, random, "aaabbbcccddd")
+
+	if child != "aaacbbcccddd" {
+		t.Errorf("expected aaacbbcccddd, got %s", child)
+	}
+	if random.Remaining() != 0 {
+		t.Errorf("expected the whole script to be used, %d left", random.Remaining())
+	}
+}
+
+func TestInsertAddsAChromosomeAnywhere(t *testing.T) {
+	insert := operator(t, "insert", "abcdef", 2)
+	// insert before the second chromosome, then draw its genes
+	random := genetictest.NewScript(t, 1, 4, 5)
+
+	child, _ := genetictest.Apply(insert, random, "aabb")
+
+	if child != "aaefbb" {
+		t.Errorf("expected aaefbb, got %s", child)
+	}
+}
+
+func TestTransplantCopiesAChromosomeFromTheOtherParent(t *testing.T) {
+	transplant := operator(t, "transplant", "abcdef", 2)
+	// copy the other parent's second chromosome to the front
+	random := genetictest.NewScript(t, 1, 0)
+
+	child, _ := genetictest.Apply(transplant, random, "aabb", "ccdd")
+
+	if child != "ddaabb" {
+		t.Errorf("expected ddaabb, got %s", child)
+	}
+}
+
+func TestSwapExchangesChromosomes(t *testing.T) {
+	swap := operator(t, "swap", "abcdef", 2)
+	// swap whole chromosomes, the third and the first
+	random := genetictest.NewScript(t, 1, 2, 0)
+
+	child, _ := genetictest.Apply(swap, random, "aabbcc")
+
+	if child != "ccbbaa" {
+		t.Errorf("expected ccbbaa, got %s", child)
+	}
+}
+
+func TestOperatorHarnessSolvesWithTheSolversOperators(t *testing.T) {
+	harness := genetictest.OperatorHarness{
+		GetFitness: func(genes string) int {
+			if len(genes) != 10 {
+				return -1
+			}
+			return strings.Count(genes, "1")
+		},
+		Operators: genetic.Operators("01", 1),
+		Random:    rand.New(rand.NewSource(3)),
+		Check: func(operator string, parents []string, child string) {
+			genetictest.AssertAligned(t, child, 1)
+		},
+	}
+	improvements := harness.Run([]string{"0000000000", "0000000001"}, 2000)
+
+	best := improvements[len(improvements)-1]
+	if best.Fitness != 10 {
+		t.Errorf("expected to find all ones, got %+v", best)
+	}
+}
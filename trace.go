@@ -0,0 +1,144 @@
+package genetic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// TraceFormat selects how a TraceWriter encodes events.
+type TraceFormat int
+
+const (
+	// JSONLines writes one JSON object per line.
+	JSONLines TraceFormat = iota
+	// CSV writes a header row followed by one row per event.
+	CSV
+)
+
+var traceFormatNames = []string{"jsonl", "csv"}
+
+func (format TraceFormat) String() string {
+	return enumName(traceFormatNames, int(format))
+}
+
+func (format TraceFormat) MarshalText() ([]byte, error) {
+	return []byte(format.String()), nil
+}
+
+func (format *TraceFormat) UnmarshalText(text []byte) error {
+	value, err := parseEnumName(traceFormatNames, "TraceFormat", string(text))
+	*format = TraceFormat(value)
+	return err
+}
+
+// TraceWriter is an Observer that records every event it receives so a
+// run can be plotted or compared offline.
+type TraceWriter struct {
+	format TraceFormat
+
+	lock          sync.Mutex
+	json          *json.Encoder
+	csv           *csv.Writer
+	headerWritten bool
+	err           error
+}
+
+type traceRecord struct {
+	Time           string  `json:"time"`
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+	Evaluations    int64   `json:"evaluations"`
+	Event          string  `json:"event"`
+	Evolver        int     `json:"evolver,omitempty"`
+	Strategy       string  `json:"strategy,omitempty"`
+	Fitness        *int    `json:"fitness,omitempty"`
+	PoolSize       int     `json:"poolSize,omitempty"`
+	Selections     int     `json:"selections,omitempty"`
+	Successes      int     `json:"successes,omitempty"`
+	Genes          string  `json:"genes,omitempty"`
+}
+
+var traceColumns = []string{"time", "elapsed_seconds", "evaluations", "event", "evolver", "strategy", "fitness", "pool_size", "selections", "successes", "genes"}
+
+func NewTraceWriter(w io.Writer, format TraceFormat) *TraceWriter {
+	trace := TraceWriter{format: format}
+	if format == CSV {
+		trace.csv = csv.NewWriter(w)
+	} else {
+		trace.json = json.NewEncoder(w)
+	}
+	return &trace
+}
+
+func (trace *TraceWriter) Observe(event Event) {
+	record := traceRecord{
+		Time:           event.Time.Format(time.RFC3339Nano),
+		ElapsedSeconds: event.Elapsed.Seconds(),
+		Evaluations:    event.Evaluations,
+		Event:          string(event.Kind),
+		Evolver:        event.Evolver,
+		Strategy:       event.Strategy,
+		PoolSize:       event.PoolSize,
+		Selections:     event.Selections,
+		Successes:      event.Successes,
+		Genes:          event.Genes,
+	}
+	if event.Kind == ImprovementEvent || event.Kind == EvolverImprovementEvent {
+		fitness := event.Fitness
+		record.Fitness = &fitness
+	}
+
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+	if trace.err != nil {
+		return
+	}
+	if trace.format == CSV {
+		trace.err = trace.writeCSV(record)
+	} else {
+		trace.err = trace.json.Encode(record)
+	}
+}
+
+// Err returns the first error encountered while writing, after which the
+// TraceWriter stops writing.
+func (trace *TraceWriter) Err() error {
+	trace.lock.Lock()
+	defer trace.lock.Unlock()
+	return trace.err
+}
+
+func (trace *TraceWriter) writeCSV(record traceRecord) error {
+	if !trace.headerWritten {
+		if err := trace.csv.Write(traceColumns); err != nil {
+			return err
+		}
+		trace.headerWritten = true
+	}
+
+	fitness := ""
+	if record.Fitness != nil {
+		fitness = strconv.Itoa(*record.Fitness)
+	}
+	err := trace.csv.Write([]string{
+		record.Time,
+		strconv.FormatFloat(record.ElapsedSeconds, 'f', -1, 64),
+		strconv.FormatInt(record.Evaluations, 10),
+		record.Event,
+		strconv.Itoa(record.Evolver),
+		record.Strategy,
+		fitness,
+		strconv.Itoa(record.PoolSize),
+		strconv.Itoa(record.Selections),
+		strconv.Itoa(record.Successes),
+		record.Genes,
+	})
+	if err != nil {
+		return err
+	}
+	trace.csv.Flush()
+	return trace.csv.Error()
+}
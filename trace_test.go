@@ -0,0 +1,65 @@
+package genetic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+)
+
+func TestTraceWriterWritesJSONLines(t *testing.T) {
+	var output bytes.Buffer
+	solver := new(Solver)
+	solver.MaxSecondsToRunWithoutImprovement = .1
+	solver.Observers = []Observer{NewTraceWriter(&output, JSONLines)}
+
+	target := "hello"
+	solver.GetBest(func(candidate string) int {
+		return len(target) - HammingDistance(target, candidate)
+	}, func(string) {}, "ehlo", len(target), 1)
+
+	kinds := map[string]int{}
+	scanner := bufio.NewScanner(&output)
+	for scanner.Scan() {
+		var record traceRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid record %q: %v", scanner.Text(), err)
+		}
+		if record.Time == "" {
+			t.Errorf("record without timestamp: %q", scanner.Text())
+		}
+		kinds[record.Event]++
+	}
+
+	if kinds[string(ImprovementEvent)] == 0 {
+		t.Error("expected improvement records")
+	}
+	if kinds[string(StrategySummaryEvent)] == 0 {
+		t.Error("expected strategy summary records")
+	}
+	if kinds[string(EvolverImprovementEvent)] == 0 {
+		t.Error("expected evolver improvement records")
+	}
+}
+
+func TestTraceWriterWritesCSVWithHeader(t *testing.T) {
+	var output bytes.Buffer
+	trace := NewTraceWriter(&output, CSV)
+	trace.Observe(Event{Kind: ImprovementEvent, Evolver: 1, Strategy: "mutate", Fitness: 3, Genes: "a,b"})
+	trace.Observe(Event{Kind: EvolverRestartedEvent, Evolver: 2})
+
+	rows, err := csv.NewReader(&output).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %v", rows)
+	}
+	if rows[0][0] != "time" || rows[1][3] != "improvement" || rows[1][6] != "3" || rows[1][10] != "a,b" {
+		t.Errorf("unexpected rows %v", rows)
+	}
+	if rows[2][6] != "" {
+		t.Errorf("expected no fitness on a restart row, got %q", rows[2][6])
+	}
+}
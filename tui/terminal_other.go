@@ -0,0 +1,17 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package tui
+
+import (
+	"io"
+	"os"
+)
+
+// makeRaw leaves the terminal alone, so keys take effect after Enter.
+func makeRaw(terminal *os.File) func() {
+	return func() {}
+}
+
+func terminalWidth(output io.Writer) int {
+	return 80
+}
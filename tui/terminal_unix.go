@@ -0,0 +1,50 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package tui
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// makeRaw turns off line buffering and echo so single key presses can be
+// read, leaving signals such as Ctrl-C alone. It returns a function that
+// restores the previous settings.
+func makeRaw(terminal *os.File) func() {
+	var original syscall.Termios
+	if ioctl(terminal.Fd(), getTermios, unsafe.Pointer(&original)) != nil {
+		return func() {}
+	}
+	raw := original
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if ioctl(terminal.Fd(), setTermios, unsafe.Pointer(&raw)) != nil {
+		return func() {}
+	}
+	return func() {
+		ioctl(terminal.Fd(), setTermios, unsafe.Pointer(&original))
+	}
+}
+
+func terminalWidth(output io.Writer) int {
+	file, ok := output.(*os.File)
+	if !ok {
+		return 80
+	}
+	var size struct{ rows, columns, x, y uint16 }
+	if ioctl(file.Fd(), syscall.TIOCGWINSZ, unsafe.Pointer(&size)) != nil || size.columns == 0 {
+		return 80
+	}
+	return int(size.columns)
+}
+
+func ioctl(fd uintptr, request uintptr, argument unsafe.Pointer) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, uintptr(argument))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
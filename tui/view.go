@@ -0,0 +1,276 @@
+// Package tui draws a full-screen live view of a run in the terminal:
+// the best fitness with a sparkline of its history, a stagnation clock,
+// per-evolver status and each strategy's share of the improvements.
+//
+//	view := tui.New(solver)
+//	best := solver.GetBest(getFitness, func(string) {}, geneSet, 10, 1)
+//	view.Close()
+//
+// Keys: p pauses, r resumes, space toggles, s or q stops the run and d
+// dumps every evolver's pool to a file. Pass a display function that
+// does not print, the view shows the best genes itself.
+package tui
+
+import (
+	"fmt"
+	genetic "github.com/handcraftsman/GeneticGo"
+	"io"
+	"os"
+	s "sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// View is a genetic.Observer that redraws the terminal several times a
+// second until closed.
+type View struct {
+	solver     *genetic.Solver
+	output     io.Writer
+	maxSeconds float64
+	restore    func()
+
+	lock      sync.Mutex
+	fitnesses []int
+	best      genetic.Event
+	stopping  bool
+	message   string
+
+	done    chan struct{}
+	drawing sync.WaitGroup
+}
+
+// New takes over the terminal and adds the view to the solver's
+// Observers. Configure the solver before calling it.
+func New(solver *genetic.Solver) *View {
+	view := &View{
+		solver:     solver,
+		output:     os.Stdout,
+		maxSeconds: solver.MaxSecondsToRunWithoutImprovement,
+		restore:    makeRaw(os.Stdin),
+		done:       make(chan struct{}),
+	}
+	if view.maxSeconds == 0 {
+		view.maxSeconds = 20 // the solver's default
+	}
+	solver.Observers = append(solver.Observers, view)
+
+	fmt.Fprint(view.output, "\x1b[?1049h\x1b[?25l") // alternate screen, hide cursor
+	go view.readKeys(os.Stdin)
+	view.drawing.Add(1)
+	go view.redraw()
+	return view
+}
+
+// Observe records improvements for the sparkline and best genes.
+func (view *View) Observe(event genetic.Event) {
+	if event.Kind != genetic.ImprovementEvent {
+		return
+	}
+	view.lock.Lock()
+	defer view.lock.Unlock()
+	view.best = event
+	view.fitnesses = append(view.fitnesses, event.Fitness)
+	if len(view.fitnesses) > 200 {
+		view.fitnesses = view.fitnesses[len(view.fitnesses)-200:]
+	}
+}
+
+// Close stops drawing and gives the terminal back.
+func (view *View) Close() {
+	select {
+	case <-view.done:
+		return
+	default:
+	}
+	close(view.done)
+	view.drawing.Wait()
+	fmt.Fprint(view.output, "\x1b[?25h\x1b[?1049l")
+	view.restore()
+}
+
+func (view *View) redraw() {
+	defer view.drawing.Done()
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		view.draw()
+		select {
+		case <-view.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// readKeys runs until the process exits since a read from the terminal
+// cannot be interrupted; keys arriving after Close are ignored.
+func (view *View) readKeys(input io.Reader) {
+	key := make([]byte, 1)
+	for {
+		if _, err := input.Read(key); err != nil {
+			return
+		}
+		select {
+		case <-view.done:
+			return
+		default:
+		}
+
+		switch key[0] {
+		case 'p':
+			view.solver.Pause()
+		case 'r':
+			view.solver.Resume()
+		case ' ':
+			if view.solver.Paused() {
+				view.solver.Resume()
+			} else {
+				view.solver.Pause()
+			}
+		case 's', 'q':
+			view.lock.Lock()
+			view.stopping = true
+			view.lock.Unlock()
+			view.solver.Stop()
+		case 'd':
+			view.setMessage(view.dumpPools())
+		}
+		view.draw()
+	}
+}
+
+func (view *View) setMessage(message string) {
+	view.lock.Lock()
+	defer view.lock.Unlock()
+	view.message = message
+}
+
+// dumpPools writes every evolver's pool to a new file in the current
+// directory and returns a message saying where.
+func (view *View) dumpPools() string {
+	name := "pool-" + time.Now().Format("20060102-150405") + ".txt"
+	file, err := os.Create(name)
+	if err != nil {
+		return err.Error()
+	}
+	defer file.Close()
+
+	candidates := view.solver.Pools()
+	fmt.Fprintln(file, "evolver\tfitness\tstrategy\tgenes")
+	for _, candidate := range candidates {
+		fmt.Fprintf(file, "%d\t%d\t%s\t%s\n", candidate.Evolver, candidate.Fitness, candidate.Strategy, candidate.Genes)
+	}
+	return fmt.Sprintf("%d pool members written to %s", len(candidates), name)
+}
+
+func (view *View) draw() {
+	view.lock.Lock()
+	current := frame{
+		statistics: view.solver.Statistics(),
+		maxSeconds: view.maxSeconds,
+		fitnesses:  append([]int(nil), view.fitnesses...),
+		best:       view.best,
+		message:    view.message,
+		width:      terminalWidth(view.output),
+		status:     "running",
+	}
+	if view.stopping {
+		current.status = "stopping"
+	} else if view.solver.Paused() {
+		current.status = "paused"
+	}
+	view.lock.Unlock()
+
+	io.WriteString(view.output, "\x1b[H"+current.render()+"\x1b[J")
+}
+
+type frame struct {
+	statistics genetic.Statistics
+	maxSeconds float64
+	fitnesses  []int
+	best       genetic.Event
+	message    string
+	width      int
+	status     string
+}
+
+func (f frame) render() string {
+	var lines []string
+	add := func(format string, a ...interface{}) {
+		line := []rune(fmt.Sprintf(format, a...))
+		if len(line) > f.width {
+			line = line[:f.width]
+		}
+		lines = append(lines, string(line))
+	}
+	statistics := f.statistics
+
+	add("GeneticGo  %-8s  elapsed %s  evaluations %d (%.0f/s)",
+		f.status, statistics.Elapsed.Round(100*time.Millisecond), statistics.Evaluations, statistics.EvaluationsPerSecond())
+	add("")
+	if f.best.Kind == "" {
+		add("best       none yet")
+	} else {
+		add("best       %d from %s in evolver %d at %s",
+			f.best.Fitness, f.best.Strategy, f.best.Evolver, f.best.Elapsed.Round(100*time.Millisecond))
+		add("history    %s", sparkline(f.fitnesses, f.width-11))
+		add("genes      %s", f.best.Genes)
+	}
+
+	add("")
+	add("%-8s %12s %6s %10s %12s %10s", "evolver", "best", "pool", "diversity", "unimproved", "stops in")
+	for _, evolver := range statistics.Evolvers {
+		stopsIn := f.maxSeconds - evolver.SinceImprovement.Seconds()
+		add("%-8d %12d %6d %10.1f %11.1fs %9.1fs",
+			evolver.Id, evolver.BestFitness, evolver.PoolSize, evolver.Diversity,
+			evolver.SinceImprovement.Seconds(), max(0, stopsIn))
+	}
+
+	add("")
+	add("%-12s %10s %7s", "strategy", "successes", "share")
+	total := 0
+	names := make([]string, 0, len(statistics.Improvements))
+	for name, count := range statistics.Improvements {
+		names = append(names, name)
+		total += count
+	}
+	s.Slice(names, func(i, j int) bool {
+		return statistics.Improvements[names[i]] > statistics.Improvements[names[j]] ||
+			statistics.Improvements[names[i]] == statistics.Improvements[names[j]] && names[i] < names[j]
+	})
+	for _, name := range names {
+		count := statistics.Improvements[name]
+		add("%-12s %10d %6.0f%%", name, count, 100*float64(count)/float64(total))
+	}
+
+	add("")
+	add("[p]ause [r]esume [s]top [d]ump pool  %s", f.message)
+	return strings.Join(lines, "\x1b[K\r\n") + "\x1b[K"
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline draws the last width values, scaled between their minimum
+// and maximum.
+func sparkline(values []int, width int) string {
+	if width < 1 || len(values) == 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	lowest, highest := values[0], values[0]
+	for _, value := range values {
+		lowest, highest = min(lowest, value), max(highest, value)
+	}
+	line := make([]rune, len(values))
+	for i, value := range values {
+		level := len(sparks) - 1
+		if highest != lowest {
+			level = (value - lowest) * (len(sparks) - 1) / (highest - lowest)
+		}
+		line[i] = sparks[level]
+	}
+	return string(line)
+}
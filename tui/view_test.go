@@ -0,0 +1,49 @@
+package tui
+
+import (
+	genetic "github.com/handcraftsman/GeneticGo"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSparkline(t *testing.T) {
+	if line := sparkline([]int{1, 5, 9}, 10); line != "▁▄█" {
+		t.Errorf("got %q", line)
+	}
+	if line := sparkline([]int{1, 2, 3, 4}, 2); line != "▁█" {
+		t.Errorf("expected only the last 2 values, got %q", line)
+	}
+	if line := sparkline([]int{7, 7}, 10); line != "██" {
+		t.Errorf("got %q", line)
+	}
+}
+
+func TestRender(t *testing.T) {
+	f := frame{
+		statistics: genetic.Statistics{
+			Elapsed:      3 * time.Second,
+			Evaluations:  300,
+			Improvements: map[string]int{"mutate": 3, "crossover": 1},
+			Evolvers:     []genetic.EvolverStatistics{{Id: 1, BestFitness: 42, PoolSize: 20, SinceImprovement: 5 * time.Second}},
+		},
+		maxSeconds: 20,
+		fitnesses:  []int{10, 42},
+		best:       genetic.Event{Kind: genetic.ImprovementEvent, Fitness: 42, Strategy: "mutate", Evolver: 1, Genes: "abc"},
+		width:      80,
+		message:    strings.Repeat("x", 100),
+		status:     "paused",
+	}
+	screen := f.render()
+
+	for _, expected := range []string{"paused", "(100/s)", "best       42 from mutate", "genes      abc", "15.0s", "mutate                3     75%", "crossover             1     25%"} {
+		if !strings.Contains(screen, expected) {
+			t.Errorf("expected %q in\n%s", expected, screen)
+		}
+	}
+	for _, line := range strings.Split(screen, "\r\n") {
+		if len([]rune(strings.TrimSuffix(line, "\x1b[K"))) > 80 {
+			t.Errorf("line wider than the terminal: %q", line)
+		}
+	}
+}
@@ -1,21 +1,51 @@
 package genetic
 
+import (
+	"sync"
+)
+
 type sequenceInfo struct {
-	genes     string
+	genes     []byte // never changed once the sequence is shared
 	fitness   int
 	strategy  strategyInfo
 	parent    *sequenceInfo
 	evolverId int
+	behavior  []float64
+	score     float64
 }
 
 type strategyInfo struct {
-	name         string
-	start        func(strategyIndex int)
-	successCount int
-	results      chan *sequenceInfo
-	index        int
+	name           string
+	start          func(strategy strategyInfo)
+	successCount   int
+	selectionCount int
+	results        chan *sequenceInfo
+	index          int
 }
 
-type randomSource interface {
+// RandomSource supplies the random choices made by the solver and its
+// operators; *rand.Rand satisfies it.
+type RandomSource interface {
 	Intn(exclusiveMax int) int
 }
+
+// spareChildren holds children that were thrown away before anything but
+// their strategy and evaluation saw them, genes buffer and all, so most
+// children cost no allocations.
+var spareChildren = sync.Pool{New: func() any { return new(sequenceInfo) }}
+
+// newChild returns a sequence with empty genes and room for at least
+// length of them.
+func newChild(length int) *sequenceInfo {
+	child := spareChildren.Get().(*sequenceInfo)
+	if cap(child.genes) < length {
+		child.genes = make([]byte, 0, length)
+	}
+	return child
+}
+
+// discard recycles a child nothing refers to.
+func discard(child *sequenceInfo) {
+	*child = sequenceInfo{genes: child.genes[:0]}
+	spareChildren.Put(child)
+}